@@ -12,6 +12,8 @@ type AccountsService struct {
 
 // List retrieves all accounts for the authenticated user
 func (s *AccountsService) List(ctx context.Context) ([]Account, error) {
+	ctx = withOperation(ctx, "Accounts.List", 0, nil)
+
 	path := "/accounts"
 
 	req, err := s.client.newRequest(ctx, "GET", path, nil)
@@ -29,6 +31,8 @@ func (s *AccountsService) List(ctx context.Context) ([]Account, error) {
 
 // Get retrieves a single account by ID with quota and API stats
 func (s *AccountsService) Get(ctx context.Context, accountID string) (*Account, error) {
+	ctx = withOperation(ctx, "Accounts.Get", 0, accountID)
+
 	path := fmt.Sprintf("/accounts/%s", accountID)
 
 	req, err := s.client.newRequest(ctx, "GET", path, nil)
@@ -46,6 +50,8 @@ func (s *AccountsService) Get(ctx context.Context, accountID string) (*Account,
 
 // ListUsers retrieves all users for an account
 func (s *AccountsService) ListUsers(ctx context.Context, accountID string) ([]AccountUser, error) {
+	ctx = withOperation(ctx, "Accounts.ListUsers", 0, accountID)
+
 	path := fmt.Sprintf("/accounts/%s/users", accountID)
 
 	req, err := s.client.newRequest(ctx, "GET", path, nil)
@@ -63,6 +69,8 @@ func (s *AccountsService) ListUsers(ctx context.Context, accountID string) ([]Ac
 
 // GetUser retrieves a single user by ID
 func (s *AccountsService) GetUser(ctx context.Context, accountID string, userID int) (*AccountUser, error) {
+	ctx = withOperation(ctx, "Accounts.GetUser", 0, userID)
+
 	path := fmt.Sprintf("/accounts/%s/users/%d", accountID, userID)
 
 	req, err := s.client.newRequest(ctx, "GET", path, nil)
@@ -80,6 +88,8 @@ func (s *AccountsService) GetUser(ctx context.Context, accountID string, userID
 
 // UpdateUser updates a user's role
 func (s *AccountsService) UpdateUser(ctx context.Context, accountID string, userID int, role string) error {
+	ctx = withOperation(ctx, "Accounts.UpdateUser", 0, userID)
+
 	path := fmt.Sprintf("/accounts/%s/users/%d", accountID, userID)
 
 	reqBody := AccountUserUpdateRequest{}
@@ -96,6 +106,8 @@ func (s *AccountsService) UpdateUser(ctx context.Context, accountID string, user
 
 // RemoveUser removes a user from an account
 func (s *AccountsService) RemoveUser(ctx context.Context, accountID string, userID int) error {
+	ctx = withOperation(ctx, "Accounts.RemoveUser", 0, userID)
+
 	path := fmt.Sprintf("/accounts/%s/users/%d", accountID, userID)
 
 	req, err := s.client.newRequest(ctx, "DELETE", path, nil)
@@ -108,6 +120,8 @@ func (s *AccountsService) RemoveUser(ctx context.Context, accountID string, user
 
 // ListInvitations retrieves all invitations for an account
 func (s *AccountsService) ListInvitations(ctx context.Context, accountID string) ([]AccountInvitation, error) {
+	ctx = withOperation(ctx, "Accounts.ListInvitations", 0, accountID)
+
 	path := fmt.Sprintf("/accounts/%s/invitations", accountID)
 
 	req, err := s.client.newRequest(ctx, "GET", path, nil)
@@ -125,6 +139,8 @@ func (s *AccountsService) ListInvitations(ctx context.Context, accountID string)
 
 // GetInvitation retrieves a single invitation by ID
 func (s *AccountsService) GetInvitation(ctx context.Context, accountID string, invitationID int) (*AccountInvitation, error) {
+	ctx = withOperation(ctx, "Accounts.GetInvitation", 0, invitationID)
+
 	path := fmt.Sprintf("/accounts/%s/invitations/%d", accountID, invitationID)
 
 	req, err := s.client.newRequest(ctx, "GET", path, nil)
@@ -142,6 +158,8 @@ func (s *AccountsService) GetInvitation(ctx context.Context, accountID string, i
 
 // CreateInvitation creates a new account invitation
 func (s *AccountsService) CreateInvitation(ctx context.Context, accountID string, params AccountInvitationParams) (*AccountInvitation, error) {
+	ctx = withOperation(ctx, "Accounts.CreateInvitation", 0, accountID)
+
 	path := fmt.Sprintf("/accounts/%s/invitations", accountID)
 
 	reqBody := AccountInvitationRequest{Invitation: params}
@@ -161,6 +179,8 @@ func (s *AccountsService) CreateInvitation(ctx context.Context, accountID string
 
 // UpdateInvitation updates an existing account invitation
 func (s *AccountsService) UpdateInvitation(ctx context.Context, accountID string, invitationID int, params AccountInvitationParams) error {
+	ctx = withOperation(ctx, "Accounts.UpdateInvitation", 0, invitationID)
+
 	path := fmt.Sprintf("/accounts/%s/invitations/%d", accountID, invitationID)
 
 	reqBody := AccountInvitationRequest{Invitation: params}
@@ -176,6 +196,8 @@ func (s *AccountsService) UpdateInvitation(ctx context.Context, accountID string
 
 // DeleteInvitation deletes an account invitation
 func (s *AccountsService) DeleteInvitation(ctx context.Context, accountID string, invitationID int) error {
+	ctx = withOperation(ctx, "Accounts.DeleteInvitation", 0, invitationID)
+
 	path := fmt.Sprintf("/accounts/%s/invitations/%d", accountID, invitationID)
 
 	req, err := s.client.newRequest(ctx, "DELETE", path, nil)
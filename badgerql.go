@@ -0,0 +1,531 @@
+package honeybadgerapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BadgerQLExpr is a BadgerQL expression: a field, a literal, a function call,
+// or an aliased expression. Build expressions with Field, Literal, Func and
+// the aggregate helpers so that names are validated and values escaped.
+type BadgerQLExpr struct {
+	s   string
+	err error
+}
+
+// String returns the rendered expression
+func (e BadgerQLExpr) String() string {
+	return e.s
+}
+
+// As aliases the expression, e.g. Count().As("requests") renders "count() as requests"
+func (e BadgerQLExpr) As(alias string) BadgerQLExpr {
+	if e.err != nil {
+		return e
+	}
+	if !badgerQLIdentifier.MatchString(alias) {
+		return BadgerQLExpr{err: fmt.Errorf("invalid alias %q", alias)}
+	}
+	return BadgerQLExpr{s: e.s + " as " + alias}
+}
+
+var (
+	badgerQLField      = regexp.MustCompile(`^@?[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z0-9_]+)*(::[a-z]+)?$`)
+	badgerQLIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// Field references an event field such as "duration", "@ts" or a typed
+// field such as "user.id::int"
+func Field(name string) BadgerQLExpr {
+	if !badgerQLField.MatchString(name) {
+		return BadgerQLExpr{err: fmt.Errorf("invalid field name %q", name)}
+	}
+	return BadgerQLExpr{s: name}
+}
+
+// Literal renders a Go value as a BadgerQL literal. Strings and times are
+// quoted and escaped, so user input cannot change the shape of the query.
+// Supported types are strings, booleans, integers, floats, time.Time and nil.
+func Literal(v interface{}) BadgerQLExpr {
+	switch v := v.(type) {
+	case nil:
+		return BadgerQLExpr{s: "null"}
+	case string:
+		return BadgerQLExpr{s: quoteBadgerQLString(v)}
+	case bool:
+		return BadgerQLExpr{s: strconv.FormatBool(v)}
+	case int:
+		return BadgerQLExpr{s: strconv.Itoa(v)}
+	case int64:
+		return BadgerQLExpr{s: strconv.FormatInt(v, 10)}
+	case int32:
+		return BadgerQLExpr{s: strconv.FormatInt(int64(v), 10)}
+	case uint:
+		return BadgerQLExpr{s: strconv.FormatUint(uint64(v), 10)}
+	case uint64:
+		return BadgerQLExpr{s: strconv.FormatUint(v, 10)}
+	case float32:
+		return Literal(float64(v))
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return BadgerQLExpr{err: fmt.Errorf("cannot use %v as a BadgerQL literal", v)}
+		}
+		return BadgerQLExpr{s: strconv.FormatFloat(v, 'f', -1, 64)}
+	case time.Time:
+		return BadgerQLExpr{s: quoteBadgerQLString(v.UTC().Format(time.RFC3339Nano))}
+	case BadgerQLExpr:
+		return v
+	}
+	return BadgerQLExpr{err: fmt.Errorf("unsupported BadgerQL literal type %T", v)}
+}
+
+// quoteBadgerQLString quotes s as a double-quoted string, escaping quotes,
+// backslashes and control characters
+func quoteBadgerQLString(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s) // Encoding a string cannot fail
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// Func calls a BadgerQL function, e.g. Func("percentile", Field("duration"), Literal(95))
+func Func(name string, args ...BadgerQLExpr) BadgerQLExpr {
+	if !badgerQLIdentifier.MatchString(name) {
+		return BadgerQLExpr{err: fmt.Errorf("invalid function name %q", name)}
+	}
+	parts := make([]string, len(args))
+	for i, arg := range args {
+		if arg.err != nil {
+			return arg
+		}
+		parts[i] = arg.s
+	}
+	return BadgerQLExpr{s: name + "(" + strings.Join(parts, ", ") + ")"}
+}
+
+// Count returns count()
+func Count() BadgerQLExpr { return Func("count") }
+
+// Sum returns sum(field)
+func Sum(field string) BadgerQLExpr { return Func("sum", Field(field)) }
+
+// Avg returns avg(field)
+func Avg(field string) BadgerQLExpr { return Func("avg", Field(field)) }
+
+// Min returns min(field)
+func Min(field string) BadgerQLExpr { return Func("min", Field(field)) }
+
+// Max returns max(field)
+func Max(field string) BadgerQLExpr { return Func("max", Field(field)) }
+
+// Bin groups timestamps into buckets of the given width for stats ... by.
+// A zero width renders bin(), which lets the server choose a width.
+func Bin(width time.Duration) BadgerQLExpr {
+	if width < 0 || width%time.Second != 0 {
+		return BadgerQLExpr{err: fmt.Errorf("bin width must be a whole number of seconds, got %s", width)}
+	}
+	if width == 0 {
+		return Func("bin")
+	}
+	return BadgerQLExpr{s: "bin(" + formatBadgerQLInterval(width) + ")"}
+}
+
+// formatBadgerQLInterval renders a duration in its largest whole unit, e.g. 1d, 15m
+func formatBadgerQLInterval(d time.Duration) string {
+	units := []struct {
+		size   time.Duration
+		suffix string
+	}{
+		{24 * time.Hour, "d"},
+		{time.Hour, "h"},
+		{time.Minute, "m"},
+		{time.Second, "s"},
+	}
+	for _, unit := range units {
+		if d%unit.size == 0 {
+			return strconv.FormatInt(int64(d/unit.size), 10) + unit.suffix
+		}
+	}
+	return d.String()
+}
+
+// BadgerQLCond is a filter condition. Build conditions with Eq, Ne, Gt, Gte,
+// Lt, Lte, And, Or and Not.
+type BadgerQLCond struct {
+	s   string
+	err error
+}
+
+// String returns the rendered condition
+func (c BadgerQLCond) String() string {
+	return c.s
+}
+
+func badgerQLCompare(field, op string, value interface{}) BadgerQLCond {
+	f := Field(field)
+	if f.err != nil {
+		return BadgerQLCond{err: f.err}
+	}
+	v := Literal(value)
+	if v.err != nil {
+		return BadgerQLCond{err: v.err}
+	}
+	return BadgerQLCond{s: f.s + " " + op + " " + v.s}
+}
+
+// Eq matches events where field equals value
+func Eq(field string, value interface{}) BadgerQLCond { return badgerQLCompare(field, "==", value) }
+
+// Ne matches events where field does not equal value
+func Ne(field string, value interface{}) BadgerQLCond { return badgerQLCompare(field, "!=", value) }
+
+// Gt matches events where field is greater than value
+func Gt(field string, value interface{}) BadgerQLCond { return badgerQLCompare(field, ">", value) }
+
+// Gte matches events where field is greater than or equal to value
+func Gte(field string, value interface{}) BadgerQLCond { return badgerQLCompare(field, ">=", value) }
+
+// Lt matches events where field is less than value
+func Lt(field string, value interface{}) BadgerQLCond { return badgerQLCompare(field, "<", value) }
+
+// Lte matches events where field is less than or equal to value
+func Lte(field string, value interface{}) BadgerQLCond { return badgerQLCompare(field, "<=", value) }
+
+// And matches events that satisfy every condition
+func And(conds ...BadgerQLCond) BadgerQLCond { return badgerQLJoin("and", conds) }
+
+// Or matches events that satisfy any condition
+func Or(conds ...BadgerQLCond) BadgerQLCond { return badgerQLJoin("or", conds) }
+
+// Not matches events that do not satisfy the condition
+func Not(cond BadgerQLCond) BadgerQLCond {
+	if cond.err != nil {
+		return cond
+	}
+	return BadgerQLCond{s: "not (" + cond.s + ")"}
+}
+
+func badgerQLJoin(op string, conds []BadgerQLCond) BadgerQLCond {
+	if len(conds) == 0 {
+		return BadgerQLCond{err: fmt.Errorf("%s requires at least one condition", op)}
+	}
+	parts := make([]string, len(conds))
+	for i, cond := range conds {
+		if cond.err != nil {
+			return cond
+		}
+		parts[i] = cond.s
+		if len(conds) > 1 {
+			parts[i] = "(" + cond.s + ")"
+		}
+	}
+	return BadgerQLCond{s: strings.Join(parts, " "+op+" ")}
+}
+
+// BadgerQLSort orders results by an expression
+type BadgerQLSort struct {
+	Expr       BadgerQLExpr
+	Descending bool
+}
+
+// Asc sorts by an expression in ascending order
+func Asc(expr BadgerQLExpr) BadgerQLSort { return BadgerQLSort{Expr: expr} }
+
+// Desc sorts by an expression in descending order
+func Desc(expr BadgerQLExpr) BadgerQLSort { return BadgerQLSort{Expr: expr, Descending: true} }
+
+// badgerQLStage is one pipeline stage, e.g. "filter ..." or "limit 10"
+type badgerQLStage struct {
+	command string
+	args    string
+}
+
+// BadgerQLBuilder builds a BadgerQL query for InsightsQueryRequest.BadgerQL.
+// Stages are rendered in the order they are added:
+//
+//	q := hbapi.BadgerQL().
+//	    Filter(hbapi.Eq("event_type::str", "request")).
+//	    Stats(hbapi.Count(), hbapi.Avg("duration")).By(hbapi.Bin(time.Hour)).
+//	    Sort(hbapi.Desc(hbapi.Field("count"))).
+//	    Limit(10)
+//
+// The first invalid name or value is reported by Build.
+type BadgerQLBuilder struct {
+	stages []badgerQLStage
+	err    error
+}
+
+// BadgerQL returns an empty BadgerQL query
+func BadgerQL() *BadgerQLBuilder {
+	return &BadgerQLBuilder{}
+}
+
+func (q *BadgerQLBuilder) add(command, args string, err error) *BadgerQLBuilder {
+	if err != nil {
+		if q.err == nil {
+			q.err = fmt.Errorf("%s: %w", command, err)
+		}
+		return q
+	}
+	q.stages = append(q.stages, badgerQLStage{command: command, args: args})
+	return q
+}
+
+func renderExprs(exprs []BadgerQLExpr) (string, error) {
+	if len(exprs) == 0 {
+		return "", errors.New("at least one expression is required")
+	}
+	parts := make([]string, len(exprs))
+	for i, expr := range exprs {
+		if expr.err != nil {
+			return "", expr.err
+		}
+		parts[i] = expr.s
+	}
+	return strings.Join(parts, ", "), nil
+}
+
+// Fields selects the fields to return
+func (q *BadgerQLBuilder) Fields(exprs ...BadgerQLExpr) *BadgerQLBuilder {
+	args, err := renderExprs(exprs)
+	return q.add("fields", args, err)
+}
+
+// Filter keeps events matching a condition
+func (q *BadgerQLBuilder) Filter(cond BadgerQLCond) *BadgerQLBuilder {
+	return q.add("filter", cond.s, cond.err)
+}
+
+// Stats aggregates events; follow it with By to group the results
+func (q *BadgerQLBuilder) Stats(aggregates ...BadgerQLExpr) *BadgerQLBuilder {
+	args, err := renderExprs(aggregates)
+	return q.add("stats", args, err)
+}
+
+// By groups the preceding Stats stage, e.g. By(Bin(time.Hour), Field("event_type"))
+func (q *BadgerQLBuilder) By(groups ...BadgerQLExpr) *BadgerQLBuilder {
+	if q.err != nil {
+		return q
+	}
+	last := len(q.stages) - 1
+	if last < 0 || q.stages[last].command != "stats" || strings.Contains(q.stages[last].args, " by ") {
+		q.err = errors.New("by must follow stats")
+		return q
+	}
+	args, err := renderExprs(groups)
+	if err != nil {
+		q.err = fmt.Errorf("by: %w", err)
+		return q
+	}
+	q.stages[last].args += " by " + args
+	return q
+}
+
+// Sort orders results by one or more expressions
+func (q *BadgerQLBuilder) Sort(keys ...BadgerQLSort) *BadgerQLBuilder {
+	if len(keys) == 0 {
+		return q.add("sort", "", errors.New("at least one sort key is required"))
+	}
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		if key.Expr.err != nil {
+			return q.add("sort", "", key.Expr.err)
+		}
+		parts[i] = key.Expr.s
+		if key.Descending {
+			parts[i] += " desc"
+		}
+	}
+	return q.add("sort", strings.Join(parts, ", "), nil)
+}
+
+// Limit caps the number of results. Calling it when the query already ends
+// with a limit replaces that limit.
+func (q *BadgerQLBuilder) Limit(n int) *BadgerQLBuilder {
+	if n < 1 {
+		return q.add("limit", "", fmt.Errorf("limit must be positive, got %d", n))
+	}
+	if last := len(q.stages) - 1; last >= 0 && q.stages[last].command == "limit" {
+		q.stages = q.stages[:last]
+	}
+	return q.add("limit", strconv.Itoa(n), nil)
+}
+
+// Parse extracts new fields from a field with a regular expression using
+// named capture groups, e.g. Parse(Field("path"), `/users/(?<user_id>\d+)`)
+func (q *BadgerQLBuilder) Parse(field BadgerQLExpr, pattern string) *BadgerQLBuilder {
+	if field.err != nil {
+		return q.add("parse", "", field.err)
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		return q.add("parse", "", fmt.Errorf("invalid pattern: %w", err))
+	}
+	return q.add("parse", field.s+" "+quoteBadgerQLRegexp(pattern), nil)
+}
+
+// quoteBadgerQLRegexp wraps a pattern in slashes, escaping unescaped slashes in it
+func quoteBadgerQLRegexp(pattern string) string {
+	var b strings.Builder
+	b.WriteByte('/')
+	escaped := false
+	for _, r := range pattern {
+		if r == '/' && !escaped {
+			b.WriteByte('\\')
+		}
+		escaped = r == '\\' && !escaped
+		b.WriteRune(r)
+	}
+	b.WriteByte('/')
+	return b.String()
+}
+
+// Build renders the query, or returns the first validation error
+func (q *BadgerQLBuilder) Build() (string, error) {
+	if q.err != nil {
+		return "", q.err
+	}
+	if len(q.stages) == 0 {
+		return "", errors.New("query has no stages")
+	}
+	return q.String(), nil
+}
+
+// String renders the query with one stage per line. It does not report
+// validation errors; use Build for that.
+func (q *BadgerQLBuilder) String() string {
+	lines := make([]string, len(q.stages))
+	for i, stage := range q.stages {
+		lines[i] = stage.command + " " + stage.args
+	}
+	return strings.Join(lines, "\n| ")
+}
+
+// badgerQLCommands are the stages ParseBadgerQL understands
+var badgerQLCommands = map[string]bool{
+	"fields": true, "filter": true, "stats": true, "sort": true, "limit": true, "parse": true,
+}
+
+// ParseBadgerQL parses a query made of fields, filter, stats, sort, limit and
+// parse stages into a builder, so it can be extended and rendered again.
+// Stage arguments are checked for balanced quotes, parentheses and patterns
+// but otherwise kept as written.
+func ParseBadgerQL(query string) (*BadgerQLBuilder, error) {
+	stages, err := splitBadgerQLStages(query)
+	if err != nil {
+		return nil, err
+	}
+
+	q := BadgerQL()
+	for _, text := range stages {
+		command, args, _ := strings.Cut(text, " ")
+		command = strings.ToLower(command)
+		args = strings.TrimSpace(args)
+		if !badgerQLCommands[command] {
+			return nil, fmt.Errorf("unsupported BadgerQL stage %q", command)
+		}
+		if args == "" {
+			return nil, fmt.Errorf("BadgerQL stage %q has no arguments", command)
+		}
+		if command == "limit" {
+			n, err := strconv.Atoi(args)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid limit %q", args)
+			}
+		}
+		q.stages = append(q.stages, badgerQLStage{command: command, args: args})
+	}
+	if len(q.stages) == 0 {
+		return nil, errors.New("query has no stages")
+	}
+	return q, nil
+}
+
+// splitBadgerQLStages splits a query on the pipes between stages, ignoring
+// pipes inside strings, parentheses and the patterns of parse stages
+func splitBadgerQLStages(query string) ([]string, error) {
+	var stages []string
+	var current strings.Builder
+	var quote rune // The open quote or pattern delimiter, if any
+	depth := 0
+	escaped := false
+
+	flush := func() {
+		stages = append(stages, strings.Join(strings.Fields(current.String()), " "))
+		current.Reset()
+	}
+
+	for _, r := range query {
+		switch {
+		case escaped:
+			escaped = false
+		case quote != 0 && r == '\\':
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '/' && isParseStage(current.String()):
+			quote = r
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+			if depth < 0 {
+				return nil, errors.New("unbalanced parentheses in BadgerQL query")
+			}
+		case r == '|' && depth == 0:
+			flush()
+			continue
+		}
+		current.WriteRune(r)
+	}
+	if quote != 0 {
+		return nil, errors.New("unterminated string or pattern in BadgerQL query")
+	}
+	if depth != 0 {
+		return nil, errors.New("unbalanced parentheses in BadgerQL query")
+	}
+	flush()
+
+	if len(stages) == 1 && stages[0] == "" {
+		return nil, errors.New("query has no stages")
+	}
+	for _, stage := range stages {
+		if stage == "" {
+			return nil, errors.New("empty stage in BadgerQL query")
+		}
+	}
+	return stages, nil
+}
+
+func isParseStage(text string) bool {
+	fields := strings.Fields(text)
+	return len(fields) > 0 && strings.EqualFold(fields[0], "parse")
+}
+
+// resolveBadgerQL renders BadgerQL into Query
+func (r InsightsQueryRequest) resolveBadgerQL() (InsightsQueryRequest, error) {
+	if r.BadgerQL == nil {
+		return r, nil
+	}
+	if r.Query != "" {
+		return r, errors.New("insights query request sets both Query and BadgerQL")
+	}
+	query, err := r.BadgerQL.Build()
+	if err != nil {
+		return r, fmt.Errorf("invalid BadgerQL query: %w", err)
+	}
+	r.Query = query
+	r.BadgerQL = nil
+	return r, nil
+}
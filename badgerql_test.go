@@ -0,0 +1,215 @@
+package honeybadgerapi
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBadgerQL_Build(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    *BadgerQLBuilder
+		expected string
+	}{
+		{
+			name: "stats by bin",
+			query: BadgerQL().
+				Filter(Eq("event_type::str", "request")).
+				Stats(Count().As("requests"), Avg("duration")).By(Bin(time.Hour)).
+				Sort(Desc(Field("requests"))).
+				Limit(10),
+			expected: "filter event_type::str == \"request\"\n| stats count() as requests, avg(duration) by bin(1h)\n| sort requests desc\n| limit 10",
+		},
+		{
+			name:     "fields",
+			query:    BadgerQL().Fields(Field("@ts"), Field("user.id::int"), Func("round", Field("duration"), Literal(2)).As("ms")),
+			expected: "fields @ts, user.id::int, round(duration, 2) as ms",
+		},
+		{
+			name:     "escaped literal",
+			query:    BadgerQL().Filter(Eq("message::str", "say \"hi\" | \\o/\n")),
+			expected: `filter message::str == "say \"hi\" | \\o/\n"`,
+		},
+		{
+			name:     "combined conditions",
+			query:    BadgerQL().Filter(And(Gte("duration", 1.5), Or(Eq("status", 500), Not(Eq("ok", true))))),
+			expected: "filter (duration >= 1.5) and ((status == 500) or (not (ok == true)))",
+		},
+		{
+			name:     "parse escapes slashes",
+			query:    BadgerQL().Parse(Field("path::str"), `/users/(?P<id>\d+)`),
+			expected: `parse path::str /\/users\/(?P<id>\d+)/`,
+		},
+		{
+			name:     "limit replaces trailing limit",
+			query:    BadgerQL().Fields(Field("a")).Limit(5).Limit(20),
+			expected: "fields a\n| limit 20",
+		},
+		{
+			name:     "default bin and day bin",
+			query:    BadgerQL().Stats(Sum("bytes")).By(Bin(0), Bin(48*time.Hour)),
+			expected: "stats sum(bytes) by bin(), bin(2d)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.query.Build()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestBadgerQL_BuildErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		query *BadgerQLBuilder
+	}{
+		{"empty", BadgerQL()},
+		{"invalid field", BadgerQL().Fields(Field("a b"))},
+		{"injected field", BadgerQL().Filter(Eq("x | limit 1", 1))},
+		{"invalid alias", BadgerQL().Stats(Count().As("a-b"))},
+		{"unsupported literal", BadgerQL().Filter(Eq("a", []string{"x"}))},
+		{"NaN literal", BadgerQL().Filter(Eq("a", math.NaN()))},
+		{"by without stats", BadgerQL().Fields(Field("a")).By(Field("b"))},
+		{"by twice", BadgerQL().Stats(Count()).By(Field("a")).By(Field("b"))},
+		{"zero limit", BadgerQL().Limit(0)},
+		{"invalid pattern", BadgerQL().Parse(Field("a"), "(")},
+		{"fractional bin", BadgerQL().Stats(Count()).By(Bin(1500 * time.Millisecond))},
+		{"no sort keys", BadgerQL().Sort()},
+		{"empty or", BadgerQL().Filter(Or())},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.query.Build(); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestParseBadgerQL(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{
+			name:     "normalizes whitespace",
+			query:    "  filter  status == 500 |stats count() by bin(1h)|   limit 5 ",
+			expected: "filter status == 500\n| stats count() by bin(1h)\n| limit 5",
+		},
+		{
+			name:     "pipes inside strings and patterns",
+			query:    `filter msg::str == "a | b" | parse path /(users|teams)\/(?P<id>\d+)/ | fields id`,
+			expected: "filter msg::str == \"a | b\"\n| parse path /(users|teams)\\/(?P<id>\\d+)/\n| fields id",
+		},
+		{
+			name:     "round trips builder output",
+			query:    BadgerQL().Filter(Ne("env", "a|b")).Stats(Count()).By(Field("env")).Sort(Asc(Field("count"))).String(),
+			expected: "filter env != \"a|b\"\n| stats count() by env\n| sort count",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := ParseBadgerQL(tt.query)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := q.String(); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseBadgerQL_Edit(t *testing.T) {
+	q, err := ParseBadgerQL("stats count() by env | limit 5")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := q.Limit(50).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := "stats count() by env\n| limit 50"; got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+
+	q, err = ParseBadgerQL("stats count()")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := q.By(Field("env")).Build(); err != nil {
+		t.Errorf("expected By to extend a parsed stats stage, got %v", err)
+	}
+}
+
+func TestParseBadgerQL_Errors(t *testing.T) {
+	for _, query := range []string{
+		"",
+		"fields a |",
+		"| fields a",
+		"fields a || fields b",
+		"explode a",
+		"limit ten",
+		"limit",
+		`filter a == "unterminated`,
+		"parse a /unterminated",
+		"stats count(",
+		"fields a)",
+	} {
+		if _, err := ParseBadgerQL(query); err == nil {
+			t.Errorf("expected an error for %q", query)
+		}
+	}
+}
+
+func TestInsightsQuery_BadgerQL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+		expected := "filter status == 500\n| stats count()"
+		if body["query"] != expected {
+			t.Errorf("expected query %q, got %v", expected, body["query"])
+		}
+		if _, ok := body["BadgerQL"]; ok {
+			t.Error("expected the builder not to be sent")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results": [], "meta": {}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient().WithBaseURL(server.URL).WithAuthToken("test-token")
+	ctx := context.Background()
+
+	_, err := client.Insights.Query(ctx, 123, InsightsQueryRequest{
+		BadgerQL: BadgerQL().Filter(Eq("status", 500)).Stats(Count()),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = client.Insights.Query(ctx, 123, InsightsQueryRequest{BadgerQL: BadgerQL().Fields(Field("bad name"))})
+	if err == nil || !strings.Contains(err.Error(), "invalid field name") {
+		t.Errorf("expected a validation error, got %v", err)
+	}
+
+	_, err = client.Insights.Query(ctx, 123, InsightsQueryRequest{Query: "fields a", BadgerQL: BadgerQL().Fields(Field("a"))})
+	if err == nil {
+		t.Error("expected an error when both Query and BadgerQL are set")
+	}
+}
@@ -0,0 +1,163 @@
+package honeybadgerapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DefaultBulkConcurrency is the number of updates BulkUpdate keeps in flight when none is set
+const DefaultBulkConcurrency = 4
+
+// FaultAction describes a change BulkUpdate applies to each matching fault
+type FaultAction struct {
+	name   string
+	params func(Fault) (FaultUpdateParams, bool) // Returns false if the fault needs no change
+}
+
+// String returns a short description of the action, e.g. "resolve"
+func (a FaultAction) String() string {
+	return a.name
+}
+
+// ResolveAction resolves faults that are not already resolved
+func ResolveAction() FaultAction {
+	return FaultAction{name: "resolve", params: func(f Fault) (FaultUpdateParams, bool) {
+		resolved := true
+		return FaultUpdateParams{Resolved: &resolved}, !f.Resolved
+	}}
+}
+
+// IgnoreAction ignores faults that are not already ignored
+func IgnoreAction() FaultAction {
+	return FaultAction{name: "ignore", params: func(f Fault) (FaultUpdateParams, bool) {
+		ignored := true
+		return FaultUpdateParams{Ignored: &ignored}, !f.Ignored
+	}}
+}
+
+// AssignAction assigns faults to a user, skipping faults already assigned to them
+func AssignAction(userID int) FaultAction {
+	return FaultAction{name: fmt.Sprintf("assign to %d", userID), params: func(f Fault) (FaultUpdateParams, bool) {
+		return FaultUpdateParams{AssigneeID: &userID}, f.Assignee == nil || f.Assignee.ID != userID
+	}}
+}
+
+// TagAction adds tags to faults, skipping faults that already have all of them.
+// Tags are normalized with NormalizeTags.
+func TagAction(tags ...string) FaultAction {
+	return FaultAction{name: "tag " + strings.Join(tags, ", "), params: func(f Fault) (FaultUpdateParams, bool) {
+		merged, changed := addTags(f.Tags, tags)
+		return FaultUpdateParams{Tags: merged}, changed
+	}}
+}
+
+// UntagAction removes tags from faults, skipping faults that have none of them
+func UntagAction(tags ...string) FaultAction {
+	return FaultAction{name: "untag " + strings.Join(tags, ", "), params: func(f Fault) (FaultUpdateParams, bool) {
+		remaining, changed := removeTags(f.Tags, tags)
+		return FaultUpdateParams{Tags: remaining}, changed
+	}}
+}
+
+// UpdateAction applies the same update to every fault
+func UpdateAction(params FaultUpdateParams) FaultAction {
+	return FaultAction{name: "update", params: func(Fault) (FaultUpdateParams, bool) {
+		return params, true
+	}}
+}
+
+// BulkUpdateOptions controls how BulkUpdate applies an action
+type BulkUpdateOptions struct {
+	Concurrency int  // Updates in flight at once; defaults to DefaultBulkConcurrency
+	DryRun      bool // Report the faults that would change without updating them
+}
+
+// BulkUpdateResult is the outcome of updating a single fault
+type BulkUpdateResult struct {
+	Fault Fault
+	Err   error // Nil if the update succeeded, or in a dry run
+}
+
+// BulkUpdateReport summarizes a bulk update
+type BulkUpdateReport struct {
+	Action  string
+	DryRun  bool
+	Results []BulkUpdateResult // Faults that needed the change, in listing order
+	Skipped []Fault            // Matching faults that already had the change applied
+}
+
+// Succeeded returns the faults that were updated
+func (r *BulkUpdateReport) Succeeded() []Fault {
+	var faults []Fault
+	for _, result := range r.Results {
+		if result.Err == nil {
+			faults = append(faults, result.Fault)
+		}
+	}
+	return faults
+}
+
+// Failed returns the results of updates that failed
+func (r *BulkUpdateReport) Failed() []BulkUpdateResult {
+	var failed []BulkUpdateResult
+	for _, result := range r.Results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Err returns the failed updates joined into one error, or nil if all succeeded
+func (r *BulkUpdateReport) Err() error {
+	var errs []error
+	for _, result := range r.Failed() {
+		errs = append(errs, fmt.Errorf("fault %d: %w", result.Fault.ID, result.Err))
+	}
+	return errors.Join(errs...)
+}
+
+// BulkUpdate applies an action to every fault matching the list options. All
+// matching faults are listed before any are updated, so updates that change
+// whether a fault matches the query do not disturb pagination.
+//
+// The returned error reports a failure to list faults; per-fault failures are
+// recorded in the report. When listing fails partway, the report covers the
+// faults listed so far and no updates are made.
+func (f *FaultsService) BulkUpdate(ctx context.Context, projectID int, options FaultListOptions, action FaultAction, bulkOptions BulkUpdateOptions) (*BulkUpdateReport, error) {
+	report := &BulkUpdateReport{Action: action.String(), DryRun: bulkOptions.DryRun}
+
+	var pending []FaultUpdateParams
+	for fault, err := range f.All(ctx, projectID, options) {
+		if err != nil {
+			return report, err
+		}
+		params, changed := action.params(fault)
+		if !changed {
+			report.Skipped = append(report.Skipped, fault)
+			continue
+		}
+		report.Results = append(report.Results, BulkUpdateResult{Fault: fault})
+		pending = append(pending, params)
+	}
+
+	if bulkOptions.DryRun {
+		return report, nil
+	}
+
+	concurrency := bulkOptions.Concurrency
+	if concurrency < 1 {
+		concurrency = DefaultBulkConcurrency
+	}
+
+	errs := forEachConcurrently(ctx, len(report.Results), concurrency, func(i int) error {
+		return f.Update(ctx, projectID, report.Results[i].Fault.ID, pending[i])
+	})
+	for i, err := range errs {
+		report.Results[i].Err = err
+	}
+
+	return report, nil
+}
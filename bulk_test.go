@@ -0,0 +1,203 @@
+package honeybadgerapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newBulkFaultsServer serves two pages of faults and records updates by fault ID.
+// Updates to fault 3 fail with a 500.
+func newBulkFaultsServer(t *testing.T, updates map[string]string, mu *sync.Mutex, inFlight, maxInFlight *int32) *httptest.Server {
+	t.Helper()
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == "/v2/projects/123/faults" {
+			if r.URL.Query().Get("q") != "class:Timeout" {
+				t.Errorf("expected q class:Timeout, got %s", r.URL.Query().Get("q"))
+			}
+			w.Header().Set("Content-Type", "application/json")
+			if r.URL.Query().Get("page") == "2" {
+				_, _ = w.Write([]byte(`{"results": [{"id": 3, "tags": []}, {"id": 4, "resolved": true, "tags": ["slow"]}], "links": {}}`))
+				return
+			}
+			_, _ = fmt.Fprintf(w, `{
+				"results": [{"id": 1, "tags": ["slow"]}, {"id": 2, "tags": ["db"]}],
+				"links": {"next": "%s/v2/projects/123/faults?page=2&q=class%%3ATimeout"}
+			}`, server.URL)
+			return
+		}
+
+		if r.Method != "PUT" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			return
+		}
+
+		n := atomic.AddInt32(inFlight, 1)
+		for {
+			max := atomic.LoadInt32(maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(inFlight, -1)
+
+		body, _ := io.ReadAll(r.Body)
+		id := strings.TrimPrefix(r.URL.Path, "/v2/projects/123/faults/")
+		mu.Lock()
+		updates[id] = string(body)
+		mu.Unlock()
+
+		if id == "3" {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"errors": "Something went wrong"}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestBulkUpdate(t *testing.T) {
+	var mu sync.Mutex
+	var inFlight, maxInFlight int32
+	updates := map[string]string{}
+	server := newBulkFaultsServer(t, updates, &mu, &inFlight, &maxInFlight)
+
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token")
+
+	report, err := client.Faults.BulkUpdate(context.Background(), 123, FaultListOptions{Q: "class:Timeout"}, ResolveAction(), BulkUpdateOptions{Concurrency: 2})
+	if err != nil {
+		t.Fatalf("BulkUpdate() error = %v", err)
+	}
+
+	if len(report.Results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(report.Results))
+	}
+	if len(report.Skipped) != 1 || report.Skipped[0].ID != 4 {
+		t.Errorf("expected already-resolved fault 4 to be skipped, got %+v", report.Skipped)
+	}
+	if len(report.Succeeded()) != 2 {
+		t.Errorf("expected 2 successes, got %d", len(report.Succeeded()))
+	}
+
+	failed := report.Failed()
+	if len(failed) != 1 || failed[0].Fault.ID != 3 {
+		t.Fatalf("expected fault 3 to fail, got %+v", failed)
+	}
+	if !errors.Is(report.Err(), ErrServer) {
+		t.Errorf("expected report error to wrap ErrServer, got %v", report.Err())
+	}
+
+	if updates["1"] != `{"fault":{"resolved":true}}` {
+		t.Errorf("unexpected update body %s", updates["1"])
+	}
+	if atomic.LoadInt32(&maxInFlight) > 2 {
+		t.Errorf("expected at most 2 concurrent updates, got %d", maxInFlight)
+	}
+}
+
+func TestBulkUpdate_DryRun(t *testing.T) {
+	var mu sync.Mutex
+	var inFlight, maxInFlight int32
+	updates := map[string]string{}
+	server := newBulkFaultsServer(t, updates, &mu, &inFlight, &maxInFlight)
+
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token")
+
+	report, err := client.Faults.BulkUpdate(context.Background(), 123, FaultListOptions{Q: "class:Timeout"}, TagAction("slow"), BulkUpdateOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("BulkUpdate() error = %v", err)
+	}
+
+	if !report.DryRun {
+		t.Error("expected report to be marked as a dry run")
+	}
+	if len(updates) != 0 {
+		t.Errorf("expected no updates in a dry run, got %d", len(updates))
+	}
+
+	var ids []int
+	for _, result := range report.Results {
+		ids = append(ids, result.Fault.ID)
+	}
+	if fmt.Sprint(ids) != "[2 3]" {
+		t.Errorf("expected faults [2 3] to be tagged, got %v", ids)
+	}
+}
+
+func TestBulkUpdate_TagMergesExistingTags(t *testing.T) {
+	var mu sync.Mutex
+	var inFlight, maxInFlight int32
+	updates := map[string]string{}
+	server := newBulkFaultsServer(t, updates, &mu, &inFlight, &maxInFlight)
+
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token")
+
+	if _, err := client.Faults.BulkUpdate(context.Background(), 123, FaultListOptions{Q: "class:Timeout"}, TagAction("slow"), BulkUpdateOptions{}); err != nil {
+		t.Fatalf("BulkUpdate() error = %v", err)
+	}
+
+	if updates["2"] != `{"fault":{"tags":["db","slow"]}}` {
+		t.Errorf("expected existing tags to be kept, got %s", updates["2"])
+	}
+	if _, ok := updates["1"]; ok {
+		t.Error("expected fault 1, which already has the tag, not to be updated")
+	}
+}
+
+func TestBulkUpdate_ListError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token")
+
+	_, err := client.Faults.BulkUpdate(context.Background(), 123, FaultListOptions{}, IgnoreAction(), BulkUpdateOptions{})
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+}
+
+func TestFaultActions(t *testing.T) {
+	fault := Fault{Resolved: true, Assignee: &User{ID: 7}, Tags: []string{"a"}}
+
+	if _, changed := ResolveAction().params(fault); changed {
+		t.Error("expected resolve to skip a resolved fault")
+	}
+	if _, changed := IgnoreAction().params(fault); !changed {
+		t.Error("expected ignore to change an unignored fault")
+	}
+	if _, changed := AssignAction(7).params(fault); changed {
+		t.Error("expected assign to skip a fault already assigned to the user")
+	}
+	if params, changed := TagAction("a", "b").params(fault); !changed || fmt.Sprint(params.Tags) != "[a b]" {
+		t.Errorf("expected tags [a b], got %v (changed %v)", params.Tags, changed)
+	}
+	if fault.Tags[0] != "a" || len(fault.Tags) != 1 {
+		t.Errorf("expected the listed fault's tags to be left alone, got %v", fault.Tags)
+	}
+	if got := AssignAction(7).String(); got != "assign to 7" {
+		t.Errorf("expected action name 'assign to 7', got %s", got)
+	}
+}
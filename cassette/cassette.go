@@ -0,0 +1,197 @@
+// Package cassette records HTTP exchanges with the Honeybadger API to a fixture
+// file and replays them offline, for deterministic integration tests.
+//
+// A Recorder is an http.RoundTripper, so it plugs into the client through
+// WithHTTPClient:
+//
+//	rec, err := cassette.New("testdata/cassettes/projects.json", cassette.ModeReplay)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer rec.Stop()
+//
+//	client := hbapi.NewClient().WithHTTPClient(rec.HTTPClient())
+//
+// Credential headers are removed from recorded requests and responses, and
+// their values are replaced with "REDACTED" wherever they also appear in a
+// URL or body, such as the API key in a check-in ping by slug. Credentials
+// sent only in a URL or body, without a matching header, are recorded as
+// is, so review new cassettes before committing them.
+package cassette
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Version is the cassette file format version written by Save
+const Version = 1
+
+// Cassette is a sequence of recorded HTTP interactions
+type Cassette struct {
+	Version      int           `json:"version"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a single recorded request and its response
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded HTTP request
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response is a recorded HTTP response
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Load reads a cassette from a file
+func Load(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var c Cassette
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+	}
+	if c.Version > Version {
+		return nil, fmt.Errorf("cassette %s has unsupported version %d", path, c.Version)
+	}
+	return &c, nil
+}
+
+// Save writes the cassette to a file, creating parent directories as needed
+func (c *Cassette) Save(path string) error {
+	c.Version = Version
+	if c.Interactions == nil {
+		c.Interactions = []Interaction{}
+	}
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// matchKey identifies a request by method, path, normalized query and normalized body.
+// The host is ignored so cassettes recorded against one region or proxy replay anywhere.
+func matchKey(method string, u *url.URL, body []byte) string {
+	return method + " " + u.Path + "?" + u.Query().Encode() + "\n" + normalizeBody(body)
+}
+
+// normalizeBody re-encodes JSON bodies so key order and whitespace do not affect matching
+func normalizeBody(body []byte) string {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return ""
+	}
+
+	var v interface{}
+	decoder := json.NewDecoder(bytes.NewReader(trimmed))
+	decoder.UseNumber()
+	if err := decoder.Decode(&v); err != nil {
+		return string(trimmed)
+	}
+	normalized, err := json.Marshal(v)
+	if err != nil {
+		return string(trimmed)
+	}
+	return string(normalized)
+}
+
+// describe formats a request for error messages
+func describe(method string, u *url.URL, body []byte) string {
+	s := method + " " + u.Path
+	if u.RawQuery != "" {
+		s += "?" + u.Query().Encode()
+	}
+	if b := normalizeBody(body); b != "" {
+		s += " " + b
+	}
+	return s
+}
+
+// scrubHeader returns a copy of h without the named headers
+func scrubHeader(h http.Header, scrubbed map[string]bool) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+	clean := http.Header{}
+	for key, values := range h {
+		if scrubbed[strings.ToLower(key)] {
+			continue
+		}
+		clean[key] = append([]string(nil), values...)
+	}
+	return clean
+}
+
+// Redacted replaces credential values in recorded URLs and bodies
+const Redacted = "REDACTED"
+
+// minSecretLength is the shortest header value redactSecrets replaces, so that
+// short values do not match unrelated text
+const minSecretLength = 4
+
+// secretValues returns the values of the named headers in h, including the
+// username and password of Basic credentials
+func secretValues(h http.Header, scrubbed map[string]bool) []string {
+	var secrets []string
+	add := func(s string) {
+		if len(s) >= minSecretLength {
+			secrets = append(secrets, s)
+		}
+	}
+	for key, values := range h {
+		if !scrubbed[strings.ToLower(key)] {
+			continue
+		}
+		for _, value := range values {
+			add(value)
+			if scheme, credentials, ok := strings.Cut(value, " "); ok {
+				add(credentials)
+				if strings.EqualFold(scheme, "Basic") {
+					if decoded, err := base64.StdEncoding.DecodeString(credentials); err == nil {
+						user, password, _ := strings.Cut(string(decoded), ":")
+						add(user)
+						add(password)
+					}
+				}
+			}
+		}
+	}
+	return secrets
+}
+
+// redactSecrets replaces each secret in s, including its URL-escaped forms,
+// with Redacted
+func redactSecrets(s string, secrets []string) string {
+	for _, secret := range secrets {
+		for _, form := range []string{secret, url.PathEscape(secret), url.QueryEscape(secret)} {
+			s = strings.ReplaceAll(s, form, Redacted)
+		}
+	}
+	return s
+}
@@ -0,0 +1,104 @@
+package cassette
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"testing"
+)
+
+func TestMatchKey(t *testing.T) {
+	a, _ := url.Parse("https://api.honeybadger.io/v2/projects/1/faults?order=recent&q=is%3Aresolved")
+	b, _ := url.Parse("http://127.0.0.1:8080/v2/projects/1/faults?q=is:resolved&order=recent")
+
+	if matchKey("GET", a, nil) != matchKey("GET", b, nil) {
+		t.Error("expected keys to match regardless of host and query order")
+	}
+	if matchKey("GET", a, nil) == matchKey("DELETE", a, nil) {
+		t.Error("expected keys to differ by method")
+	}
+
+	first := matchKey("POST", a, []byte(`{"b": 1, "a": {"y": 2, "x": 1}}`))
+	second := matchKey("POST", a, []byte(`{"a":{"x":1,"y":2},"b":1}`))
+	if first != second {
+		t.Errorf("expected JSON bodies to match regardless of key order, got %q and %q", first, second)
+	}
+	if first == matchKey("POST", a, []byte(`{"a":{"x":1,"y":2},"b":2}`)) {
+		t.Error("expected keys to differ by body")
+	}
+}
+
+func TestNormalizeBody(t *testing.T) {
+	tests := []struct {
+		body     string
+		expected string
+	}{
+		{"", ""},
+		{"  \n", ""},
+		{`{"id": 12345678901234567890}`, `{"id":12345678901234567890}`},
+		{"not json", "not json"},
+	}
+	for _, tt := range tests {
+		if got := normalizeBody([]byte(tt.body)); got != tt.expected {
+			t.Errorf("normalizeBody(%q): expected %q, got %q", tt.body, tt.expected, got)
+		}
+	}
+}
+
+func TestCassette_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "cassette.json")
+
+	c := &Cassette{Interactions: []Interaction{{
+		Request:  Request{Method: "GET", URL: "https://api.honeybadger.io/v2/projects"},
+		Response: Response{StatusCode: 200, Header: http.Header{"Content-Type": {"application/json"}}, Body: `{"results":[]}`},
+	}}}
+	if err := c.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.Version != Version {
+		t.Errorf("expected version %d, got %d", Version, loaded.Version)
+	}
+	if len(loaded.Interactions) != 1 || loaded.Interactions[0].Response.Body != `{"results":[]}` {
+		t.Errorf("unexpected interactions %+v", loaded.Interactions)
+	}
+}
+
+func TestScrubHeader(t *testing.T) {
+	h := http.Header{
+		"Authorization": {"Basic c2VjcmV0Og=="},
+		"Accept":        {"application/json"},
+	}
+	clean := scrubHeader(h, map[string]bool{"authorization": true})
+
+	if clean.Get("Authorization") != "" {
+		t.Error("expected Authorization to be scrubbed")
+	}
+	if clean.Get("Accept") != "application/json" {
+		t.Errorf("expected Accept to be kept, got %q", clean.Get("Accept"))
+	}
+	if h.Get("Authorization") == "" {
+		t.Error("expected original header to be unchanged")
+	}
+}
+
+func TestRedactSecrets(t *testing.T) {
+	h := http.Header{}
+	h.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte("my-token:")))
+	h.Set("X-API-Key", "key/with space")
+	h.Set("Accept", "application/json")
+
+	secrets := secretValues(h, map[string]bool{"authorization": true, "x-api-key": true})
+	got := redactSecrets("/v1/check_in/key%2Fwith%20space/x?token=my-token&q=key%2Fwith+space", secrets)
+	if expected := "/v1/check_in/REDACTED/x?token=REDACTED&q=REDACTED"; got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+	if got := redactSecrets("application/json", secrets); got != "application/json" {
+		t.Errorf("expected unscrubbed header values to be kept, got %s", got)
+	}
+}
@@ -0,0 +1,264 @@
+package cassette
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Mode controls whether a Recorder talks to the network
+type Mode int
+
+const (
+	// ModeReplay serves responses from the cassette. Requests without a
+	// matching recorded interaction fail with ErrNoMatch.
+	ModeReplay Mode = iota
+	// ModeRecord sends requests to the network and replaces the cassette
+	// with the recorded interactions when the recorder is stopped.
+	ModeRecord
+	// ModeAuto replays if the cassette file exists and records otherwise.
+	ModeAuto
+)
+
+// String returns the mode name
+func (m Mode) String() string {
+	switch m {
+	case ModeReplay:
+		return "replay"
+	case ModeRecord:
+		return "record"
+	case ModeAuto:
+		return "auto"
+	}
+	return fmt.Sprintf("Mode(%d)", int(m))
+}
+
+// ErrNoMatch is returned in replay mode when no recorded interaction matches a request
+var ErrNoMatch = errors.New("cassette: no recorded interaction matches request")
+
+// DefaultScrubbedHeaders are removed from recorded requests and responses
+var DefaultScrubbedHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"X-API-Key",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// Recorder is an http.RoundTripper that records or replays interactions with a cassette file.
+// It is safe for concurrent use.
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+	scrubbed  map[string]bool
+
+	mu        sync.Mutex
+	cassette  *Cassette
+	used      []bool
+	unmatched []string
+}
+
+// New creates a recorder for the cassette at path. In replay mode the cassette must exist.
+func New(path string, mode Mode) (*Recorder, error) {
+	if mode == ModeAuto {
+		mode = ModeRecord
+		if _, err := os.Stat(path); err == nil {
+			mode = ModeReplay
+		}
+	}
+
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		transport: http.DefaultTransport,
+		scrubbed:  map[string]bool{},
+		cassette:  &Cassette{Version: Version},
+	}
+	r.WithScrubbedHeaders(DefaultScrubbedHeaders...)
+
+	if mode == ModeReplay {
+		c, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		r.cassette = c
+		r.used = make([]bool, len(c.Interactions))
+	}
+	return r, nil
+}
+
+// WithTransport sets the transport used to reach the network in record mode
+func (r *Recorder) WithTransport(transport http.RoundTripper) *Recorder {
+	r.transport = transport
+	return r
+}
+
+// WithScrubbedHeaders adds headers to remove from recorded interactions. Their
+// values are also redacted from recorded URLs and bodies.
+func (r *Recorder) WithScrubbedHeaders(names ...string) *Recorder {
+	for _, name := range names {
+		r.scrubbed[strings.ToLower(name)] = true
+	}
+	return r
+}
+
+// Mode returns the recorder's effective mode
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// HTTPClient returns an HTTP client that sends requests through the recorder
+func (r *Recorder) HTTPClient() *http.Client {
+	return &http.Client{Transport: r, Timeout: 30 * time.Second}
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("cassette: failed to read request body: %w", err)
+		}
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, body)
+	}
+	return r.record(req, body)
+}
+
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	// Redact the request the same way it was redacted when recorded
+	secrets := secretValues(req.Header, r.scrubbed)
+	reqURL, err := url.Parse(redactSecrets(req.URL.String(), secrets))
+	if err != nil {
+		return nil, fmt.Errorf("cassette: failed to redact request URL: %w", err)
+	}
+	body = []byte(redactSecrets(string(body), secrets))
+	key := matchKey(req.Method, reqURL, body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] {
+			continue
+		}
+		u, err := url.Parse(interaction.Request.URL)
+		if err != nil {
+			continue
+		}
+		if matchKey(interaction.Request.Method, u, []byte(interaction.Request.Body)) != key {
+			continue
+		}
+
+		r.used[i] = true
+		recorded := interaction.Response
+		header := recorded.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+			StatusCode:    recorded.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(recorded.Body)),
+			ContentLength: int64(len(recorded.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	description := describe(req.Method, reqURL, body)
+	r.unmatched = append(r.unmatched, description)
+	return nil, fmt.Errorf("%w: %s (cassette %s)", ErrNoMatch, description, r.path)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	outgoing := req.Clone(req.Context())
+	if body != nil {
+		outgoing.Body = io.NopCloser(bytes.NewReader(body))
+		outgoing.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+
+	resp, err := r.transport.RoundTrip(outgoing)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("cassette: failed to read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	u := *req.URL
+	u.User = nil
+	secrets := secretValues(req.Header, r.scrubbed)
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    redactSecrets(u.String(), secrets),
+			Header: scrubHeader(req.Header, r.scrubbed),
+			Body:   redactSecrets(string(body), secrets),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     scrubHeader(resp.Header, r.scrubbed),
+			Body:       redactSecrets(string(respBody), secrets),
+		},
+	})
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// Unmatched returns descriptions of the requests that found no recorded interaction
+func (r *Recorder) Unmatched() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.unmatched...)
+}
+
+// Unused returns the recorded interactions that have not been replayed
+func (r *Recorder) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unused []Interaction
+	for i, used := range r.used {
+		if !used {
+			unused = append(unused, r.cassette.Interactions[i])
+		}
+	}
+	return unused
+}
+
+// Stop finishes the session. In record mode it writes the cassette file.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cassette.Save(r.path)
+}
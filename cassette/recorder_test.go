@@ -0,0 +1,265 @@
+package cassette
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	hbapi "github.com/honeybadger-io/api-go"
+)
+
+func newProjectServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret")
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/v2/projects/1":
+			_, _ = w.Write([]byte(`{"id": 1, "name": "Shop"}`))
+		case r.Method == "POST" && r.URL.Path == "/v2/projects":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id": 2, "name": "Blog"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRecorder_RecordAndReplay(t *testing.T) {
+	server := newProjectServer(t)
+	path := filepath.Join(t.TempDir(), "projects.json")
+
+	rec, err := New(path, ModeRecord)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	client := hbapi.NewClient().WithBaseURL(server.URL).WithAuthToken("super-secret").WithHTTPClient(rec.HTTPClient())
+
+	if _, err := client.Projects.Get(context.Background(), 1); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if _, err := client.Projects.Create(context.Background(), "acct-1", hbapi.ProjectRequest{Name: "Blog"}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read cassette: %v", err)
+	}
+	if strings.Contains(string(data), "Authorization") || strings.Contains(string(data), "session=secret") {
+		t.Errorf("expected credentials to be scrubbed, got %s", data)
+	}
+
+	// Replay against a closed server to prove nothing hits the network
+	server.Close()
+	replay, err := New(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	client = hbapi.NewClient().WithBaseURL("https://api.honeybadger.io").WithAuthToken("other").WithHTTPClient(replay.HTTPClient())
+
+	created, err := client.Projects.Create(context.Background(), "acct-1", hbapi.ProjectRequest{Name: "Blog"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if created.ID != 2 {
+		t.Errorf("expected project ID 2, got %d", created.ID)
+	}
+	project, err := client.Projects.Get(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if project.Name != "Shop" {
+		t.Errorf("expected project name Shop, got %s", project.Name)
+	}
+	if unused := replay.Unused(); len(unused) != 0 {
+		t.Errorf("expected all interactions to be used, got %d unused", len(unused))
+	}
+}
+
+func TestRecorder_ReplayUnmatched(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.json")
+	if err := (&Cassette{}).Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	rec, err := New(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	client := hbapi.NewClient().WithAuthToken("test-token").WithHTTPClient(rec.HTTPClient())
+
+	_, err = client.Projects.Get(context.Background(), 1)
+	if !errors.Is(err, ErrNoMatch) {
+		t.Fatalf("expected ErrNoMatch, got %v", err)
+	}
+	if unmatched := rec.Unmatched(); len(unmatched) != 1 || unmatched[0] != "GET /v2/projects/1" {
+		t.Errorf("expected unmatched GET /v2/projects/1, got %v", unmatched)
+	}
+}
+
+func TestRecorder_ReplayUsesEachInteractionOnce(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "twice.json")
+	c := &Cassette{Interactions: []Interaction{
+		{Request: Request{Method: "GET", URL: "/v2/projects/1"}, Response: Response{StatusCode: 200, Body: `{"id": 1, "name": "First"}`}},
+		{Request: Request{Method: "GET", URL: "/v2/projects/1"}, Response: Response{StatusCode: 200, Body: `{"id": 1, "name": "Second"}`}},
+	}}
+	if err := c.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	rec, err := New(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	client := hbapi.NewClient().WithAuthToken("test-token").WithHTTPClient(rec.HTTPClient())
+
+	for _, expected := range []string{"First", "Second"} {
+		project, err := client.Projects.Get(context.Background(), 1)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if project.Name != expected {
+			t.Errorf("expected project name %s, got %s", expected, project.Name)
+		}
+	}
+	if _, err := client.Projects.Get(context.Background(), 1); !errors.Is(err, ErrNoMatch) {
+		t.Errorf("expected ErrNoMatch once interactions are used up, got %v", err)
+	}
+}
+
+func TestNew_ModeAuto(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "auto.json")
+
+	rec, err := New(path, ModeAuto)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if rec.Mode() != ModeRecord {
+		t.Errorf("expected record mode for a missing cassette, got %s", rec.Mode())
+	}
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+
+	rec, err = New(path, ModeAuto)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if rec.Mode() != ModeReplay {
+		t.Errorf("expected replay mode for an existing cassette, got %s", rec.Mode())
+	}
+}
+
+func TestNew_MissingCassette(t *testing.T) {
+	_, err := New(filepath.Join(t.TempDir(), "missing.json"), ModeReplay)
+	if !os.IsNotExist(err) {
+		t.Errorf("expected not-exist error, got %v", err)
+	}
+}
+
+func TestRecorder_RedactsKeyInURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("OK"))
+	}))
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "ping.json")
+
+	rec, err := New(path, ModeRecord)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	client := hbapi.NewClient().WithReportingURL(server.URL).WithAPIKey("SECRETKEY").WithHTTPClient(rec.HTTPClient())
+	if err := client.CheckIns.Ping(context.Background(), hbapi.CheckInBySlug("nightly"), hbapi.CheckInPingOptions{}); err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read cassette: %v", err)
+	}
+	if strings.Contains(string(data), "SECRETKEY") {
+		t.Errorf("expected the API key to be redacted, got %s", data)
+	}
+	if !strings.Contains(string(data), "/v1/check_in/REDACTED/nightly") {
+		t.Errorf("expected a redacted ping URL, got %s", data)
+	}
+
+	// Replays with any key, since the key is redacted before matching
+	replay, err := New(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	client = hbapi.NewClient().WithAPIKey("test-key").WithHTTPClient(replay.HTTPClient())
+	if err := client.CheckIns.Ping(context.Background(), hbapi.CheckInBySlug("nightly"), hbapi.CheckInPingOptions{}); err != nil {
+		t.Fatalf("Ping() replay error = %v", err)
+	}
+}
+
+func TestRecorder_ReplaysSourceMapUpload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	newUpload := func() hbapi.SourceMapUpload {
+		dir := t.TempDir()
+		for name, content := range map[string]string{"app.js": "console.log(1)", "app.js.map": `{"version":3}`} {
+			if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return hbapi.SourceMapUpload{
+			MinifiedURL:  "https://example.com/app.js",
+			MinifiedFile: filepath.Join(dir, "app.js"),
+			SourceMap:    filepath.Join(dir, "app.js.map"),
+		}
+	}
+	path := filepath.Join(t.TempDir(), "upload.json")
+
+	rec, err := New(path, ModeRecord)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	client := hbapi.NewClient().WithReportingURL(server.URL).WithAPIKey("SECRETKEY").WithHTTPClient(rec.HTTPClient())
+	if err := client.SourceMaps.Upload(context.Background(), newUpload()); err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+	if err := rec.Stop(); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read cassette: %v", err)
+	}
+	if strings.Contains(string(data), "SECRETKEY") {
+		t.Errorf("expected the API key not to be recorded, got %s", data)
+	}
+	if !strings.Contains(string(data), `name=\"api_key\"\r\n\r\nREDACTED`) {
+		t.Errorf("expected the api_key form field to be redacted, got %s", data)
+	}
+
+	replay, err := New(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	client = hbapi.NewClient().WithAPIKey("test-key").WithHTTPClient(replay.HTTPClient())
+	// Replay from another directory, as from a different checkout
+	if err := client.SourceMaps.Upload(context.Background(), newUpload()); err != nil {
+		t.Fatalf("Upload() replay error = %v", err)
+	}
+}
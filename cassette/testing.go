@@ -0,0 +1,73 @@
+package cassette
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	hbapi "github.com/honeybadger-io/api-go"
+)
+
+// RecordEnv is the environment variable that switches test helpers into record mode
+// when set to a non-empty value other than "0" or "false"
+const RecordEnv = "HONEYBADGER_RECORD"
+
+// TokenEnv is the environment variable NewTestClient reads the API token from when recording
+const TokenEnv = "HONEYBADGER_PERSONAL_AUTH_TOKEN"
+
+// Dir is the directory, relative to the package under test, where test cassettes are stored
+var Dir = filepath.Join("testdata", "cassettes")
+
+// NewTest returns a recorder for the cassette named name in Dir. It replays
+// unless RecordEnv is set, in which case it records and writes the cassette
+// when the test finishes. Unmatched requests fail the test.
+func NewTest(tb testing.TB, name string) *Recorder {
+	tb.Helper()
+
+	mode := ModeReplay
+	if recording() {
+		mode = ModeRecord
+	}
+
+	path := filepath.Join(Dir, name+".json")
+	r, err := New(path, mode)
+	if err != nil {
+		if os.IsNotExist(err) {
+			tb.Fatalf("cassette %s not found; run with %s=1 to record it", path, RecordEnv)
+		}
+		tb.Fatalf("failed to open cassette: %v", err)
+	}
+
+	tb.Cleanup(func() {
+		for _, unmatched := range r.Unmatched() {
+			tb.Errorf("cassette %s: unmatched request %s", path, unmatched)
+		}
+		if err := r.Stop(); err != nil {
+			tb.Errorf("failed to save cassette %s: %v", path, err)
+		}
+	})
+	return r
+}
+
+// NewTestClient returns a client that talks through a test recorder. When
+// recording it authenticates with the token in TokenEnv; when replaying the
+// token is a placeholder, since recorded requests carry no credentials.
+func NewTestClient(tb testing.TB, name string) *hbapi.Client {
+	tb.Helper()
+
+	r := NewTest(tb, name)
+	token := "replay-token"
+	if r.Mode() == ModeRecord {
+		token = os.Getenv(TokenEnv)
+		if token == "" {
+			tb.Fatalf("%s must be set to record cassette %s", TokenEnv, name)
+		}
+	}
+	return hbapi.NewClient().WithHTTPClient(r.HTTPClient()).WithAuthToken(token)
+}
+
+func recording() bool {
+	v := strings.ToLower(os.Getenv(RecordEnv))
+	return v != "" && v != "0" && v != "false"
+}
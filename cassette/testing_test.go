@@ -0,0 +1,72 @@
+package cassette
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewTestClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, _, _ := r.BasicAuth(); user != "recording-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": 7, "name": "Recorded"}`))
+	}))
+	defer server.Close()
+
+	dir := Dir
+	Dir = t.TempDir()
+	defer func() { Dir = dir }()
+
+	// Record in a subtest so the cassette is written by its cleanup
+	t.Run("record", func(t *testing.T) {
+		t.Setenv(RecordEnv, "1")
+		t.Setenv(TokenEnv, "recording-token")
+
+		client := NewTestClient(t, "project").WithBaseURL(server.URL)
+		if _, err := client.Projects.Get(context.Background(), 7); err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+	})
+
+	if _, err := Load(filepath.Join(Dir, "project.json")); err != nil {
+		t.Fatalf("expected cassette to be written: %v", err)
+	}
+
+	t.Run("replay", func(t *testing.T) {
+		t.Setenv(RecordEnv, "")
+
+		client := NewTestClient(t, "project")
+		project, err := client.Projects.Get(context.Background(), 7)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if project.Name != "Recorded" {
+			t.Errorf("expected project name Recorded, got %s", project.Name)
+		}
+	})
+}
+
+func TestRecording(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected bool
+	}{
+		{"", false},
+		{"0", false},
+		{"false", false},
+		{"1", true},
+		{"true", true},
+	}
+	for _, tt := range tests {
+		t.Setenv(RecordEnv, tt.value)
+		if got := recording(); got != tt.expected {
+			t.Errorf("%s=%q: expected %v, got %v", RecordEnv, tt.value, tt.expected, got)
+		}
+	}
+}
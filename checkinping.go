@@ -0,0 +1,143 @@
+package honeybadgerapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// CheckInByID returns a check-in reference for pinging by ID
+func CheckInByID(id string) *CheckIn {
+	return &CheckIn{ID: id}
+}
+
+// CheckInBySlug returns a check-in reference for pinging by slug. Pinging by
+// slug requires the project API key set by WithAPIKey.
+func CheckInBySlug(slug string) *CheckIn {
+	return &CheckIn{Slug: slug}
+}
+
+// CheckInPingOptions adds job details to a check-in ping
+type CheckInPingOptions struct {
+	Duration   time.Duration // Sent as duration in milliseconds when non-zero
+	ExitStatus *int          // Sent as exit_status when set
+}
+
+// CheckInPingError reports a failure to ping a check-in after a job succeeded
+type CheckInPingError struct {
+	Err error
+}
+
+func (e *CheckInPingError) Error() string {
+	return fmt.Sprintf("check-in ping failed: %v", e.Err)
+}
+
+func (e *CheckInPingError) Unwrap() error {
+	return e.Err
+}
+
+// pingURL returns the URL to ping for a check-in: its URL if it was returned
+// by the API, otherwise one built from its ID or slug
+func (s *CheckInsService) pingURL(checkIn *CheckIn) (string, error) {
+	switch {
+	case checkIn == nil:
+		return "", errors.New("check-in is required")
+	case checkIn.URL != "":
+		return checkIn.URL, nil
+	case checkIn.ID != "":
+		return fmt.Sprintf("%s/v1/check_in/%s", s.client.reportingURL, url.PathEscape(checkIn.ID)), nil
+	case checkIn.Slug != "":
+		if s.client.apiKey == "" {
+			return "", ErrMissingAPIKey
+		}
+		return fmt.Sprintf("%s/v1/check_in/%s/%s", s.client.reportingURL, url.PathEscape(s.client.apiKey), url.PathEscape(checkIn.Slug)), nil
+	}
+	return "", errors.New("check-in has no URL, ID or slug")
+}
+
+// Ping reports a check-in. The check-in can come from Get or List, in which
+// case its URL is used, or from CheckInByID or CheckInBySlug.
+//
+// Honeybadger API docs: https://docs.honeybadger.io/api/reporting-check-ins/
+//
+// GET /v1/check_in/{checkInID}
+// GET /v1/check_in/{apiKey}/{slug}
+func (s *CheckInsService) Ping(ctx context.Context, checkIn *CheckIn, options CheckInPingOptions) error {
+	pingURL, err := s.pingURL(checkIn)
+	if err != nil {
+		return err
+	}
+	ctx = withOperation(ctx, "CheckIns.Ping", 0, firstNonEmpty(checkIn.ID, checkIn.Slug))
+
+	if options.Duration > 0 || options.ExitStatus != nil {
+		// Merge into any query the check-in's URL already has
+		u, err := url.Parse(pingURL)
+		if err != nil {
+			return fmt.Errorf("invalid check-in URL: %w", err)
+		}
+		params := u.Query()
+		if options.Duration > 0 {
+			params.Set("duration", strconv.FormatInt(options.Duration.Milliseconds(), 10))
+		}
+		if options.ExitStatus != nil {
+			params.Set("exit_status", strconv.Itoa(*options.ExitStatus))
+		}
+		u.RawQuery = params.Encode()
+		pingURL = u.String()
+	}
+
+	req, err := s.client.buildRequest(ctx, "GET", pingURL, nil)
+	if err != nil {
+		return err
+	}
+	if checkIn.URL == "" && checkIn.ID == "" {
+		// The slug URL embeds the API key; sending it as a header too marks it
+		// as a credential, so recorders such as the cassette package redact it
+		req.Header.Set("X-API-Key", s.client.apiKey)
+	}
+
+	return s.client.do(ctx, req, nil)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// CheckInRunOptions controls what RunWithCheckIn reports
+type CheckInRunOptions struct {
+	ReportDuration   bool // Include the job's duration in the ping
+	ReportExitStatus bool // Include exit_status 0 in the ping
+}
+
+// RunWithCheckIn runs job and pings the check-in only if it returns nil, so a
+// failed job shows up as a missed check-in. The job's error is returned
+// unchanged and no ping is sent. If the job succeeds but the ping fails, a
+// *CheckInPingError is returned.
+func (s *CheckInsService) RunWithCheckIn(ctx context.Context, checkIn *CheckIn, job func(ctx context.Context) error, options CheckInRunOptions) error {
+	start := time.Now()
+	if err := job(ctx); err != nil {
+		return err
+	}
+
+	ping := CheckInPingOptions{}
+	if options.ReportDuration {
+		ping.Duration = time.Since(start)
+	}
+	if options.ReportExitStatus {
+		ping.ExitStatus = new(int)
+	}
+
+	// Report even if the job used up ctx; its work is done
+	if err := s.Ping(context.WithoutCancel(ctx), checkIn, ping); err != nil {
+		return &CheckInPingError{Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package honeybadgerapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// newPingServer records the request URIs it receives
+func newPingServer(t *testing.T, status int, pings *[]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("expected GET request, got %s", r.Method)
+		}
+		*pings = append(*pings, r.URL.RequestURI())
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("OK"))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestPing(t *testing.T) {
+	var pings []string
+	server := newPingServer(t, http.StatusOK, &pings)
+
+	client := NewClient().
+		WithReportingURL(server.URL).
+		WithAPIKey("project-key")
+	ctx := context.Background()
+
+	if err := client.CheckIns.Ping(ctx, CheckInByID("abc123"), CheckInPingOptions{}); err != nil {
+		t.Fatalf("Ping() by ID error = %v", err)
+	}
+	if err := client.CheckIns.Ping(ctx, CheckInBySlug("nightly-backup"), CheckInPingOptions{}); err != nil {
+		t.Fatalf("Ping() by slug error = %v", err)
+	}
+
+	// A check-in returned by Get carries its own URL
+	fromGet := &CheckIn{ID: "abc123", URL: server.URL + "/v1/check_in/from-get"}
+	exitStatus := 0
+	if err := client.CheckIns.Ping(ctx, fromGet, CheckInPingOptions{Duration: 1500 * time.Millisecond, ExitStatus: &exitStatus}); err != nil {
+		t.Fatalf("Ping() by URL error = %v", err)
+	}
+
+	// Options are merged into a query the URL already has
+	withQuery := &CheckIn{ID: "abc123", URL: server.URL + "/v1/check_in/from-get?source=cron"}
+	if err := client.CheckIns.Ping(ctx, withQuery, CheckInPingOptions{ExitStatus: &exitStatus}); err != nil {
+		t.Fatalf("Ping() by URL with query error = %v", err)
+	}
+
+	expected := []string{
+		"/v1/check_in/abc123",
+		"/v1/check_in/project-key/nightly-backup",
+		"/v1/check_in/from-get?duration=1500&exit_status=0",
+		"/v1/check_in/from-get?exit_status=0&source=cron",
+	}
+	if len(pings) != len(expected) {
+		t.Fatalf("expected %d pings, got %v", len(expected), pings)
+	}
+	for i := range expected {
+		if pings[i] != expected[i] {
+			t.Errorf("ping %d: expected %s, got %s", i, expected[i], pings[i])
+		}
+	}
+}
+
+func TestPing_Errors(t *testing.T) {
+	var pings []string
+	server := newPingServer(t, http.StatusNotFound, &pings)
+
+	client := NewClient().WithReportingURL(server.URL)
+	ctx := context.Background()
+
+	if err := client.CheckIns.Ping(ctx, CheckInByID("missing"), CheckInPingOptions{}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if err := client.CheckIns.Ping(ctx, CheckInBySlug("nightly-backup"), CheckInPingOptions{}); !errors.Is(err, ErrMissingAPIKey) {
+		t.Errorf("expected ErrMissingAPIKey for a slug without an API key, got %v", err)
+	}
+	if err := client.CheckIns.Ping(ctx, &CheckIn{}, CheckInPingOptions{}); err == nil {
+		t.Error("expected error for an empty check-in, got nil")
+	}
+	if err := client.CheckIns.Ping(ctx, nil, CheckInPingOptions{}); err == nil {
+		t.Error("expected error for a nil check-in, got nil")
+	}
+}
+
+func TestRunWithCheckIn(t *testing.T) {
+	var pings []string
+	server := newPingServer(t, http.StatusOK, &pings)
+
+	client := NewClient().WithReportingURL(server.URL)
+	ctx := context.Background()
+
+	err := client.CheckIns.RunWithCheckIn(ctx, CheckInByID("abc123"), func(ctx context.Context) error {
+		time.Sleep(5 * time.Millisecond)
+		return nil
+	}, CheckInRunOptions{ReportDuration: true, ReportExitStatus: true})
+	if err != nil {
+		t.Fatalf("RunWithCheckIn() error = %v", err)
+	}
+	if len(pings) != 1 {
+		t.Fatalf("expected 1 ping, got %v", pings)
+	}
+
+	ping, _ := url.Parse(pings[0])
+	if duration, err := strconv.Atoi(ping.Query().Get("duration")); err != nil || duration < 5 {
+		t.Errorf("expected a duration of at least 5ms, got %s", pings[0])
+	}
+	if ping.Query().Get("exit_status") != "0" {
+		t.Errorf("expected exit_status 0, got %s", pings[0])
+	}
+
+	jobErr := errors.New("backup failed")
+	err = client.CheckIns.RunWithCheckIn(ctx, CheckInByID("abc123"), func(ctx context.Context) error {
+		return jobErr
+	}, CheckInRunOptions{})
+	if err != jobErr {
+		t.Errorf("expected the job's error unchanged, got %v", err)
+	}
+	if len(pings) != 1 {
+		t.Errorf("expected no ping after a failed job, got %v", pings)
+	}
+}
+
+func TestRunWithCheckIn_PingFailure(t *testing.T) {
+	var pings []string
+	server := newPingServer(t, http.StatusInternalServerError, &pings)
+
+	client := NewClient().WithReportingURL(server.URL)
+
+	err := client.CheckIns.RunWithCheckIn(context.Background(), CheckInByID("abc123"), func(ctx context.Context) error {
+		return nil
+	}, CheckInRunOptions{})
+
+	var pingErr *CheckInPingError
+	if !errors.As(err, &pingErr) {
+		t.Fatalf("expected CheckInPingError, got %v", err)
+	}
+	if !errors.Is(err, ErrServer) {
+		t.Errorf("expected ErrServer, got %v", err)
+	}
+}
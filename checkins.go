@@ -12,6 +12,8 @@ type CheckInsService struct {
 
 // List retrieves all check-ins for a project
 func (s *CheckInsService) List(ctx context.Context, projectID int) ([]CheckIn, error) {
+	ctx = withOperation(ctx, "CheckIns.List", projectID, nil)
+
 	path := fmt.Sprintf("/projects/%d/check_ins", projectID)
 
 	req, err := s.client.newRequest(ctx, "GET", path, nil)
@@ -29,6 +31,8 @@ func (s *CheckInsService) List(ctx context.Context, projectID int) ([]CheckIn, e
 
 // Get retrieves a single check-in by ID
 func (s *CheckInsService) Get(ctx context.Context, projectID int, checkInID string) (*CheckIn, error) {
+	ctx = withOperation(ctx, "CheckIns.Get", projectID, checkInID)
+
 	path := fmt.Sprintf("/projects/%d/check_ins/%s", projectID, checkInID)
 
 	req, err := s.client.newRequest(ctx, "GET", path, nil)
@@ -46,6 +50,8 @@ func (s *CheckInsService) Get(ctx context.Context, projectID int, checkInID stri
 
 // Create creates a new check-in
 func (s *CheckInsService) Create(ctx context.Context, projectID int, params CheckInParams) (*CheckIn, error) {
+	ctx = withOperation(ctx, "CheckIns.Create", projectID, nil)
+
 	path := fmt.Sprintf("/projects/%d/check_ins", projectID)
 
 	reqBody := CheckInRequest{CheckIn: params}
@@ -65,6 +71,8 @@ func (s *CheckInsService) Create(ctx context.Context, projectID int, params Chec
 
 // Update updates an existing check-in
 func (s *CheckInsService) Update(ctx context.Context, projectID int, checkInID string, params CheckInParams) error {
+	ctx = withOperation(ctx, "CheckIns.Update", projectID, checkInID)
+
 	path := fmt.Sprintf("/projects/%d/check_ins/%s", projectID, checkInID)
 
 	reqBody := CheckInRequest{CheckIn: params}
@@ -81,6 +89,8 @@ func (s *CheckInsService) Update(ctx context.Context, projectID int, checkInID s
 // BulkUpdate updates all check-ins for a project
 // WARNING: An empty payload will delete all existing check-ins
 func (s *CheckInsService) BulkUpdate(ctx context.Context, projectID int, checkIns []CheckInParams) (*CheckInBulkUpdateResponse, error) {
+	ctx = withOperation(ctx, "CheckIns.BulkUpdate", projectID, nil)
+
 	path := fmt.Sprintf("/projects/%d/check_ins", projectID)
 
 	// Wrap the check-ins in the expected format
@@ -105,6 +115,8 @@ func (s *CheckInsService) BulkUpdate(ctx context.Context, projectID int, checkIn
 
 // Delete deletes a check-in
 func (s *CheckInsService) Delete(ctx context.Context, projectID int, checkInID string) error {
+	ctx = withOperation(ctx, "CheckIns.Delete", projectID, checkInID)
+
 	path := fmt.Sprintf("/projects/%d/check_ins/%s", projectID, checkInID)
 
 	req, err := s.client.newRequest(ctx, "DELETE", path, nil)
@@ -7,13 +7,26 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
 	"time"
 )
 
 type Client struct {
-	baseURL    string
-	apiToken   string
-	httpClient *http.Client
+	baseURL          string
+	reportingURL     string
+	region           Region
+	apiToken         string
+	apiKey           string
+	httpClient       *http.Client
+	retryPolicy      RetryPolicy
+	limiter          *tokenBucket
+	middleware       []Middleware
+	responseCallback func(*Response)
+	defaultAccountID string
+	defaultProjectID int
+
+	mu        sync.Mutex
+	rateLimit RateLimit
 
 	// Resource services
 	Projects     *ProjectsService
@@ -28,11 +41,18 @@ type Client struct {
 	Environments *EnvironmentsService
 	Accounts     *AccountsService
 	StatusPages  *StatusPagesService
+
+	// Reporting API services
+	Notices    *NoticesReporter
+	Events     *EventsService
+	SourceMaps *SourceMapsService
 }
 
 func NewClient() *Client {
 	c := &Client{
-		baseURL: "https://api.honeybadger.io", // Default base URL
+		baseURL:      RegionUS.APIURL(), // Default base URL
+		reportingURL: RegionUS.ReportingURL(),
+		region:       RegionUS,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
@@ -51,6 +71,9 @@ func NewClient() *Client {
 	c.Environments = &EnvironmentsService{client: c}
 	c.Accounts = &AccountsService{client: c}
 	c.StatusPages = &StatusPagesService{client: c}
+	c.Notices = &NoticesReporter{client: c}
+	c.Events = &EventsService{client: c}
+	c.SourceMaps = &SourceMapsService{client: c}
 	return c
 }
 
@@ -79,8 +102,19 @@ func (c *Client) ProjectsAPI() *ProjectsService {
 
 func (c *Client) newRequest(ctx context.Context, method, path string, body interface{}) (*http.Request, error) {
 	// Add /v2 prefix to all paths
-	url := fmt.Sprintf("%s/v2%s", c.baseURL, path)
+	req, err := c.buildRequest(ctx, method, fmt.Sprintf("%s/v2%s", c.baseURL, path), body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set HTTP Basic Auth with token as username, no password
+	req.SetBasicAuth(c.apiToken, "")
 
+	return req, nil
+}
+
+// buildRequest creates a JSON request and attaches the operation annotated on ctx
+func (c *Client) buildRequest(ctx context.Context, method, url string, body interface{}) (*http.Request, error) {
 	var buf io.Reader
 	if body != nil {
 		jsonBody, err := json.Marshal(body)
@@ -90,39 +124,40 @@ func (c *Client) newRequest(ctx context.Context, method, path string, body inter
 		buf = bytes.NewBuffer(jsonBody)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, url, buf)
+	return c.buildRawRequest(ctx, method, url, "application/json", buf, body)
+}
+
+// buildRawRequest creates a request with an encoded body. The unencoded body,
+// if any, is exposed to middleware as Operation.Body.
+func (c *Client) buildRawRequest(ctx context.Context, method, url, contentType string, data io.Reader, body interface{}) (*http.Request, error) {
+	op := operationFromContext(ctx)
+	op.Body = body
+	ctx = context.WithValue(ctx, requestOperationKey{}, &op)
+
+	req, err := http.NewRequestWithContext(ctx, method, url, data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	// Set HTTP Basic Auth with token as username, no password
-	req.SetBasicAuth(c.apiToken, "")
-	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Type", contentType)
 	req.Header.Set("Accept", "application/json")
 
 	return req, nil
 }
 
 func (c *Client) do(ctx context.Context, req *http.Request, v interface{}) error {
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		// Check if the error is due to context cancellation
-		if ctx.Err() != nil {
-			return ctx.Err()
-		}
-		return fmt.Errorf("request failed: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
+	op := requestOperation(req)
+	op.Request = req
+	op.Result = v
 
-	if resp.StatusCode >= 400 {
-		return WrapError(resp, nil)
+	rt := c.roundTrip
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		rt = c.middleware[i](rt)
 	}
 
-	if v != nil {
-		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
-			return fmt.Errorf("failed to decode response: %w", err)
-		}
-	}
+	start := time.Now()
+	err := rt(ctx, op)
+	c.recordResponse(ctx, op, time.Since(start))
 
-	return nil
+	return err
 }
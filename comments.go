@@ -12,6 +12,8 @@ type CommentsService struct {
 
 // List retrieves all comments for a specific fault
 func (s *CommentsService) List(ctx context.Context, projectID, faultID int) ([]Comment, error) {
+	ctx = withOperation(ctx, "Comments.List", projectID, faultID)
+
 	path := fmt.Sprintf("/projects/%d/faults/%d/comments", projectID, faultID)
 
 	req, err := s.client.newRequest(ctx, "GET", path, nil)
@@ -29,6 +31,8 @@ func (s *CommentsService) List(ctx context.Context, projectID, faultID int) ([]C
 
 // Get retrieves a single comment by ID
 func (s *CommentsService) Get(ctx context.Context, projectID, faultID, commentID int) (*Comment, error) {
+	ctx = withOperation(ctx, "Comments.Get", projectID, commentID)
+
 	path := fmt.Sprintf("/projects/%d/faults/%d/comments/%d", projectID, faultID, commentID)
 
 	req, err := s.client.newRequest(ctx, "GET", path, nil)
@@ -46,6 +50,8 @@ func (s *CommentsService) Get(ctx context.Context, projectID, faultID, commentID
 
 // Create creates a new comment on a fault
 func (s *CommentsService) Create(ctx context.Context, projectID, faultID int, body string) (*Comment, error) {
+	ctx = withOperation(ctx, "Comments.Create", projectID, faultID)
+
 	path := fmt.Sprintf("/projects/%d/faults/%d/comments", projectID, faultID)
 
 	reqBody := CommentRequest{}
@@ -66,6 +72,8 @@ func (s *CommentsService) Create(ctx context.Context, projectID, faultID int, bo
 
 // Update updates an existing comment
 func (s *CommentsService) Update(ctx context.Context, projectID, faultID, commentID int, body string) error {
+	ctx = withOperation(ctx, "Comments.Update", projectID, commentID)
+
 	path := fmt.Sprintf("/projects/%d/faults/%d/comments/%d", projectID, faultID, commentID)
 
 	reqBody := CommentRequest{}
@@ -82,6 +90,8 @@ func (s *CommentsService) Update(ctx context.Context, projectID, faultID, commen
 
 // Delete deletes a comment
 func (s *CommentsService) Delete(ctx context.Context, projectID, faultID, commentID int) error {
+	ctx = withOperation(ctx, "Comments.Delete", projectID, commentID)
+
 	path := fmt.Sprintf("/projects/%d/faults/%d/comments/%d", projectID, faultID, commentID)
 
 	req, err := s.client.newRequest(ctx, "DELETE", path, nil)
@@ -0,0 +1,38 @@
+package honeybadgerapi
+
+import (
+	"context"
+	"sync"
+)
+
+// forEachConcurrently calls fn for each index in [0, n) with at most
+// concurrency calls in flight, and returns the error of each call by index.
+// Once ctx is done no further calls are started, and the calls that were not
+// started get ctx.Err(). It returns after every started call has finished.
+func forEachConcurrently(ctx context.Context, n, concurrency int, fn func(i int) error) []error {
+	errs := make([]error, n)
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			for j := i; j < n; j++ {
+				errs[j] = ctx.Err()
+			}
+			wg.Wait()
+			return errs
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			errs[i] = fn(i)
+		}(i)
+	}
+	wg.Wait()
+	return errs
+}
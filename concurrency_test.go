@@ -0,0 +1,60 @@
+package honeybadgerapi
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+func TestForEachConcurrently(t *testing.T) {
+	var inFlight, maxInFlight int32
+	errs := forEachConcurrently(context.Background(), 20, 3, func(i int) error {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		if i%5 == 0 {
+			return errors.New("failed")
+		}
+		return nil
+	})
+
+	if maxInFlight > 3 {
+		t.Errorf("expected at most 3 calls in flight, got %d", maxInFlight)
+	}
+	for i, err := range errs {
+		if (err != nil) != (i%5 == 0) {
+			t.Errorf("index %d: unexpected error %v", i, err)
+		}
+	}
+}
+
+func TestForEachConcurrently_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls int32
+	errs := forEachConcurrently(ctx, 10, 1, func(i int) error {
+		atomic.AddInt32(&calls, 1)
+		if i == 2 {
+			cancel()
+		}
+		return nil
+	})
+
+	if len(errs) != 10 {
+		t.Fatalf("expected 10 errors, got %d", len(errs))
+	}
+	// The semaphore may admit one more call after cancellation
+	if calls < 3 || calls > 4 {
+		t.Errorf("expected 3 or 4 calls, got %d", calls)
+	}
+	for i := int(calls); i < 10; i++ {
+		if !errors.Is(errs[i], context.Canceled) {
+			t.Errorf("index %d: expected context.Canceled, got %v", i, errs[i])
+		}
+	}
+}
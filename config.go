@@ -0,0 +1,250 @@
+package honeybadgerapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Environment variables read by ConfigFromEnv and LoadConfig
+const (
+	EnvAuthToken  = "HONEYBADGER_PERSONAL_AUTH_TOKEN"
+	EnvAPIKey     = "HONEYBADGER_API_KEY" // Project API key for the Reporting API
+	EnvAPIURL     = "HONEYBADGER_API_URL"
+	EnvRegion     = "HONEYBADGER_REGION"      // "us" or "eu"
+	EnvTimeout    = "HONEYBADGER_TIMEOUT"     // Duration such as "30s", or whole seconds
+	EnvMaxRetries = "HONEYBADGER_MAX_RETRIES" // Retries after the first attempt
+	EnvProxyURL   = "HONEYBADGER_PROXY_URL"
+	EnvAccountID  = "HONEYBADGER_ACCOUNT_ID"
+	EnvProjectID  = "HONEYBADGER_PROJECT_ID"
+	EnvProfile    = "HONEYBADGER_PROFILE" // Profile to load from the config file
+	EnvConfigFile = "HONEYBADGER_CONFIG"  // Path of the config file used by ConfigFromEnv
+)
+
+// DefaultProfile is the profile loaded when none is named
+const DefaultProfile = "default"
+
+// Config holds client settings loaded from the environment or a config file
+type Config struct {
+	AuthToken  string        // Personal auth token
+	APIKey     string        // Project API key for the Reporting API
+	APIURL     string        // Base URL, e.g. https://api.honeybadger.io; overrides Region
+	Region     Region        // Data residency region; sets the API and reporting hosts
+	Timeout    time.Duration // HTTP client timeout; 0 keeps the default
+	MaxRetries int           // Retries after the first attempt; 0 disables retries
+	ProxyURL   string        // HTTP proxy for API requests
+	AccountID  string        // Stored on the client; read it back with DefaultAccountID
+	ProjectID  int           // Stored on the client; read it back with DefaultProjectID
+}
+
+// ConfigFromEnv reads configuration from environment variables. If
+// HONEYBADGER_CONFIG names a config file, its profile is loaded first and
+// environment variables override it.
+func ConfigFromEnv() (*Config, error) {
+	if path := os.Getenv(EnvConfigFile); path != "" {
+		return LoadConfig(path)
+	}
+
+	cfg := &Config{}
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// LoadConfig reads the profile named by HONEYBADGER_PROFILE, or the default
+// profile, from a JSON, YAML or TOML config file. Environment variables
+// override values from the file.
+//
+// The file format is chosen by extension. Top-level tables are profiles:
+//
+//	[default]
+//	auth_token = "..."
+//	timeout = "30s"
+//
+//	[eu]
+//	region = "eu"
+func LoadConfig(path string) (*Config, error) {
+	profile := os.Getenv(EnvProfile)
+	if profile == "" {
+		profile = DefaultProfile
+	}
+	return LoadConfigProfile(path, profile)
+}
+
+// LoadConfigProfile reads the named profile from a config file. Environment
+// variables override values from the file.
+func LoadConfigProfile(path, profile string) (*Config, error) {
+	profiles, err := readConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+	settings, ok := profiles[profile]
+	if !ok {
+		return nil, fmt.Errorf("profile %q not found in %s", profile, path)
+	}
+
+	cfg := &Config{}
+	for key, value := range settings {
+		if err := cfg.set(key, value); err != nil {
+			return nil, fmt.Errorf("profile %q in %s: %w", profile, path, err)
+		}
+	}
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// NewClientFromEnv creates a client configured from environment variables
+func NewClientFromEnv() (*Client, error) {
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return cfg.NewClient()
+}
+
+// NewClient creates a client with the configured settings
+func (cfg *Config) NewClient() (*Client, error) {
+	c := NewClient().
+		WithAuthToken(cfg.AuthToken).
+		WithAPIKey(cfg.APIKey).
+		WithDefaultAccountID(cfg.AccountID).
+		WithDefaultProjectID(cfg.ProjectID)
+
+	if cfg.Region != "" {
+		c.WithRegion(cfg.Region)
+	}
+	if cfg.APIURL != "" {
+		c.WithBaseURL(strings.TrimSuffix(cfg.APIURL, "/"))
+	}
+	if cfg.Timeout > 0 {
+		c.httpClient.Timeout = cfg.Timeout
+	}
+	if cfg.MaxRetries > 0 {
+		policy := DefaultRetryPolicy()
+		policy.MaxAttempts = cfg.MaxRetries + 1
+		c.WithRetryPolicy(policy)
+	}
+	if cfg.ProxyURL != "" {
+		proxy, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.Proxy = http.ProxyURL(proxy)
+		c.httpClient.Transport = transport
+	}
+
+	return c, nil
+}
+
+// configEnv maps config keys to the environment variables that override them
+var configEnv = []struct{ key, env string }{
+	{"auth_token", EnvAuthToken},
+	{"api_key", EnvAPIKey},
+	{"api_url", EnvAPIURL},
+	{"region", EnvRegion},
+	{"timeout", EnvTimeout},
+	{"max_retries", EnvMaxRetries},
+	{"proxy_url", EnvProxyURL},
+	{"account_id", EnvAccountID},
+	{"project_id", EnvProjectID},
+}
+
+func (cfg *Config) applyEnv() error {
+	for _, e := range configEnv {
+		value, ok := os.LookupEnv(e.env)
+		if !ok || value == "" {
+			continue
+		}
+		if err := cfg.set(e.key, value); err != nil {
+			return fmt.Errorf("%s: %w", e.env, err)
+		}
+	}
+	return nil
+}
+
+// set assigns a setting by its config file key
+func (cfg *Config) set(key, value string) error {
+	switch key {
+	case "auth_token":
+		cfg.AuthToken = value
+	case "api_key":
+		cfg.APIKey = value
+	case "api_url":
+		cfg.APIURL = value
+	case "region":
+		r, err := ParseRegion(value)
+		if err != nil {
+			return err
+		}
+		cfg.Region = r
+	case "timeout":
+		d, err := parseConfigDuration(value)
+		if err != nil {
+			return err
+		}
+		cfg.Timeout = d
+	case "max_retries":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid max_retries %q", value)
+		}
+		cfg.MaxRetries = n
+	case "proxy_url":
+		cfg.ProxyURL = value
+	case "account_id":
+		cfg.AccountID = value
+	case "project_id":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid project_id %q", value)
+		}
+		cfg.ProjectID = n
+	default:
+		return fmt.Errorf("unknown setting %q", key)
+	}
+	return nil
+}
+
+// parseConfigDuration accepts Go durations such as "1m30s" or whole seconds
+func parseConfigDuration(value string) (time.Duration, error) {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timeout %q", value)
+	}
+	return d, nil
+}
+
+// WithDefaultAccountID stores an account ID for callers to read back with
+// DefaultAccountID. Service methods do not use it; pass the account explicitly.
+func (c *Client) WithDefaultAccountID(accountID string) *Client {
+	c.defaultAccountID = accountID
+	return c
+}
+
+// WithDefaultProjectID stores a project ID for callers to read back with
+// DefaultProjectID. Service methods do not use it; pass the project explicitly.
+func (c *Client) WithDefaultProjectID(projectID int) *Client {
+	c.defaultProjectID = projectID
+	return c
+}
+
+// DefaultAccountID returns the configured default account, or an empty string
+func (c *Client) DefaultAccountID() string {
+	return c.defaultAccountID
+}
+
+// DefaultProjectID returns the configured default project, or 0
+func (c *Client) DefaultProjectID() int {
+	return c.defaultProjectID
+}
@@ -0,0 +1,181 @@
+package honeybadgerapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// clearConfigEnv unsets configuration variables inherited from the test environment
+func clearConfigEnv(t *testing.T) {
+	t.Helper()
+	for _, env := range []string{EnvAuthToken, EnvAPIKey, EnvAPIURL, EnvRegion, EnvTimeout, EnvMaxRetries, EnvProxyURL, EnvAccountID, EnvProjectID, EnvProfile, EnvConfigFile} {
+		t.Setenv(env, "")
+	}
+}
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	return path
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	clearConfigEnv(t)
+	t.Setenv(EnvAuthToken, "env-token")
+	t.Setenv(EnvAPIKey, "project-key")
+	t.Setenv(EnvAPIURL, "https://eu-api.honeybadger.io/")
+	t.Setenv(EnvTimeout, "45s")
+	t.Setenv(EnvMaxRetries, "2")
+	t.Setenv(EnvAccountID, "acct-1")
+	t.Setenv(EnvProjectID, "123")
+
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv() error = %v", err)
+	}
+
+	expected := Config{
+		AuthToken:  "env-token",
+		APIKey:     "project-key",
+		APIURL:     "https://eu-api.honeybadger.io/",
+		Timeout:    45 * time.Second,
+		MaxRetries: 2,
+		AccountID:  "acct-1",
+		ProjectID:  123,
+	}
+	if *cfg != expected {
+		t.Errorf("expected %+v, got %+v", expected, *cfg)
+	}
+}
+
+func TestConfigFromEnv_Invalid(t *testing.T) {
+	clearConfigEnv(t)
+	t.Setenv(EnvProjectID, "abc")
+
+	if _, err := ConfigFromEnv(); err == nil {
+		t.Error("expected error for invalid project ID, got nil")
+	}
+}
+
+func TestLoadConfig_EnvOverridesFile(t *testing.T) {
+	clearConfigEnv(t)
+	path := writeConfigFile(t, "config.toml", `
+[default]
+auth_token = "file-token"
+project_id = 1
+
+[staging]
+auth_token = "staging-token"
+timeout = 10
+`)
+
+	t.Setenv(EnvProfile, "staging")
+	t.Setenv(EnvProjectID, "99")
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if cfg.AuthToken != "staging-token" {
+		t.Errorf("expected staging-token, got %s", cfg.AuthToken)
+	}
+	if cfg.Timeout != 10*time.Second {
+		t.Errorf("expected 10s timeout, got %v", cfg.Timeout)
+	}
+	if cfg.ProjectID != 99 {
+		t.Errorf("expected project ID 99 from env, got %d", cfg.ProjectID)
+	}
+}
+
+func TestLoadConfigProfile_Errors(t *testing.T) {
+	clearConfigEnv(t)
+	path := writeConfigFile(t, "config.json", `{"default": {"auth_tokn": "typo"}}`)
+
+	if _, err := LoadConfigProfile(path, "default"); err == nil {
+		t.Error("expected error for unknown setting, got nil")
+	}
+	if _, err := LoadConfigProfile(path, "missing"); err == nil {
+		t.Error("expected error for missing profile, got nil")
+	}
+	if _, err := LoadConfigProfile(filepath.Join(t.TempDir(), "none.json"), "default"); !os.IsNotExist(err) {
+		t.Errorf("expected not-exist error, got %v", err)
+	}
+}
+
+func TestNewClientFromEnv(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, _, _ := r.BasicAuth(); user != "file-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	clearConfigEnv(t)
+	path := writeConfigFile(t, "config.yaml", "default:\n  auth_token: file-token\n  api_url: "+server.URL+"\n  max_retries: 3\n  account_id: acct-9\n")
+	t.Setenv(EnvConfigFile, path)
+
+	client, err := NewClientFromEnv()
+	if err != nil {
+		t.Fatalf("NewClientFromEnv() error = %v", err)
+	}
+	if err := client.Teams.Delete(context.Background(), 1); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if client.retryPolicy.MaxAttempts != 4 {
+		t.Errorf("expected 4 attempts, got %d", client.retryPolicy.MaxAttempts)
+	}
+	if client.DefaultAccountID() != "acct-9" {
+		t.Errorf("expected default account acct-9, got %s", client.DefaultAccountID())
+	}
+}
+
+func TestConfig_NewClient(t *testing.T) {
+	cfg := Config{
+		AuthToken: "token",
+		Timeout:   5 * time.Second,
+		ProxyURL:  "http://proxy.internal:3128",
+		ProjectID: 42,
+	}
+
+	client, err := cfg.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if client.baseURL != "https://api.honeybadger.io" {
+		t.Errorf("expected default base URL, got %s", client.baseURL)
+	}
+	if client.httpClient.Timeout != 5*time.Second {
+		t.Errorf("expected 5s timeout, got %v", client.httpClient.Timeout)
+	}
+	if client.retryPolicy.MaxAttempts != 0 {
+		t.Errorf("expected retries to stay disabled, got %d attempts", client.retryPolicy.MaxAttempts)
+	}
+	if client.DefaultProjectID() != 42 {
+		t.Errorf("expected default project 42, got %d", client.DefaultProjectID())
+	}
+
+	transport, ok := client.httpClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("expected *http.Transport, got %T", client.httpClient.Transport)
+	}
+	req, _ := http.NewRequest("GET", "https://api.honeybadger.io/v2/projects", nil)
+	proxy, err := transport.Proxy(req)
+	if err != nil || proxy == nil || proxy.Host != "proxy.internal:3128" {
+		t.Errorf("expected proxy.internal:3128, got %v (err %v)", proxy, err)
+	}
+
+	cfg.ProxyURL = "://bad"
+	if _, err := cfg.NewClient(); err == nil {
+		t.Error("expected error for invalid proxy URL, got nil")
+	}
+}
@@ -0,0 +1,208 @@
+package honeybadgerapi
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// configProfiles maps profile names to their settings, with values kept as strings
+type configProfiles map[string]map[string]string
+
+// readConfigFile parses a config file into profiles based on its extension.
+// Settings at the top level of a file are treated as the default profile.
+func readConfigFile(path string) (configProfiles, error) {
+	var parse func([]byte) (configProfiles, error)
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		parse = parseJSONConfig
+	case ".yaml", ".yml":
+		parse = parseYAMLConfig
+	case ".toml":
+		parse = parseTOMLConfig
+	default:
+		return nil, fmt.Errorf("unsupported config file extension %q", ext)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	profiles, err := parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return profiles, nil
+}
+
+func (p configProfiles) set(profile, key, value string) {
+	if p[profile] == nil {
+		p[profile] = map[string]string{}
+	}
+	p[profile][key] = value
+}
+
+func parseJSONConfig(data []byte) (configProfiles, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var raw map[string]interface{}
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	profiles := configProfiles{}
+	for name, value := range raw {
+		if settings, ok := value.(map[string]interface{}); ok {
+			profiles[name] = map[string]string{}
+			for key, v := range settings {
+				s, err := jsonScalar(v)
+				if err != nil {
+					return nil, fmt.Errorf("%s.%s: %w", name, key, err)
+				}
+				profiles.set(name, key, s)
+			}
+			continue
+		}
+		s, err := jsonScalar(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		profiles.set(DefaultProfile, name, s)
+	}
+	return profiles, nil
+}
+
+func jsonScalar(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+	return "", fmt.Errorf("unsupported value %v", v)
+}
+
+// parseTOMLConfig parses the subset of TOML used by config files: [profile]
+// tables of key = value pairs with string, integer or boolean values.
+func parseTOMLConfig(data []byte) (configProfiles, error) {
+	profiles := configProfiles{}
+	profile := DefaultProfile
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(stripComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: invalid table header", n)
+			}
+			profile = unquoteConfigValue(strings.TrimSpace(line[1 : len(line)-1]))
+			if profiles[profile] == nil {
+				profiles[profile] = map[string]string{}
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value", n)
+		}
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return nil, fmt.Errorf("line %d: missing key", n)
+		}
+		profiles.set(profile, key, unquoteConfigValue(strings.TrimSpace(value)))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// parseYAMLConfig parses the subset of YAML used by config files: top-level
+// profile keys with indented key: value mappings, or top-level settings.
+func parseYAMLConfig(data []byte) (configProfiles, error) {
+	profiles := configProfiles{}
+	profile := ""
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		raw := stripComment(scanner.Text())
+		line := strings.TrimSpace(raw)
+		if line == "" || line == "---" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key: value", n)
+		}
+		key = unquoteConfigValue(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		indented := raw[0] == ' ' || raw[0] == '\t'
+		switch {
+		case !indented && value == "":
+			profile = key
+			if profiles[profile] == nil {
+				profiles[profile] = map[string]string{}
+			}
+		case !indented:
+			profile = ""
+			profiles.set(DefaultProfile, key, unquoteConfigValue(value))
+		case profile == "":
+			return nil, fmt.Errorf("line %d: unexpected indentation", n)
+		default:
+			profiles.set(profile, key, unquoteConfigValue(value))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// stripComment removes a # comment that is not inside a quoted string
+func stripComment(line string) string {
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// unquoteConfigValue removes surrounding quotes, interpreting escapes in double-quoted strings
+func unquoteConfigValue(value string) string {
+	if len(value) >= 2 {
+		switch {
+		case value[0] == '"' && value[len(value)-1] == '"':
+			if s, err := strconv.Unquote(value); err == nil {
+				return s
+			}
+			return value[1 : len(value)-1]
+		case value[0] == '\'' && value[len(value)-1] == '\'':
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
@@ -0,0 +1,102 @@
+package honeybadgerapi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseConfigFormats(t *testing.T) {
+	expected := configProfiles{
+		"default": {"auth_token": "abc#123", "max_retries": "2"},
+		"eu":      {"api_url": "https://eu-api.honeybadger.io", "project_id": "7"},
+	}
+
+	tests := []struct {
+		name  string
+		parse func([]byte) (configProfiles, error)
+		input string
+	}{
+		{
+			name:  "json",
+			parse: parseJSONConfig,
+			input: `{
+				"default": {"auth_token": "abc#123", "max_retries": 2},
+				"eu": {"api_url": "https://eu-api.honeybadger.io", "project_id": 7}
+			}`,
+		},
+		{
+			name:  "toml",
+			parse: parseTOMLConfig,
+			input: `# Honeybadger profiles
+[default]
+auth_token = "abc#123" # quoted hashes are not comments
+max_retries = 2
+
+["eu"]
+api_url = 'https://eu-api.honeybadger.io'
+project_id = 7
+`,
+		},
+		{
+			name:  "yaml",
+			parse: parseYAMLConfig,
+			input: `---
+# Honeybadger profiles
+default:
+  auth_token: "abc#123"
+  max_retries: 2
+eu:
+  api_url: https://eu-api.honeybadger.io # EU region
+  project_id: 7
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profiles, err := tt.parse([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("parse error = %v", err)
+			}
+			if !reflect.DeepEqual(profiles, expected) {
+				t.Errorf("expected %v, got %v", expected, profiles)
+			}
+		})
+	}
+}
+
+func TestParseConfig_TopLevelSettings(t *testing.T) {
+	expected := configProfiles{"default": {"auth_token": "abc"}}
+
+	for name, parse := range map[string]func() (configProfiles, error){
+		"json": func() (configProfiles, error) { return parseJSONConfig([]byte(`{"auth_token": "abc"}`)) },
+		"toml": func() (configProfiles, error) { return parseTOMLConfig([]byte(`auth_token = "abc"`)) },
+		"yaml": func() (configProfiles, error) { return parseYAMLConfig([]byte(`auth_token: abc`)) },
+	} {
+		profiles, err := parse()
+		if err != nil {
+			t.Fatalf("%s: parse error = %v", name, err)
+		}
+		if !reflect.DeepEqual(profiles, expected) {
+			t.Errorf("%s: expected %v, got %v", name, expected, profiles)
+		}
+	}
+}
+
+func TestParseConfig_Errors(t *testing.T) {
+	if _, err := parseTOMLConfig([]byte("[default\nauth_token = 1")); err == nil {
+		t.Error("expected error for unterminated TOML table, got nil")
+	}
+	if _, err := parseTOMLConfig([]byte("auth_token")); err == nil {
+		t.Error("expected error for TOML line without =, got nil")
+	}
+	if _, err := parseYAMLConfig([]byte("  auth_token: abc")); err == nil {
+		t.Error("expected error for indented YAML without a profile, got nil")
+	}
+	if _, err := parseJSONConfig([]byte(`{"default": {"auth_token": ["a"]}}`)); err == nil {
+		t.Error("expected error for JSON array value, got nil")
+	}
+	if _, err := readConfigFile("config.ini"); err == nil {
+		t.Error("expected error for unsupported extension, got nil")
+	}
+}
@@ -3,6 +3,7 @@ package honeybadgerapi
 import (
 	"context"
 	"fmt"
+	"iter"
 	"time"
 )
 
@@ -37,6 +38,8 @@ type DashboardRequest struct {
 //
 // GET /v2/projects/{projectID}/dashboards
 func (d *DashboardsService) List(ctx context.Context, projectID int) (*DashboardListResponse, error) {
+	ctx = withOperation(ctx, "Dashboards.List", projectID, nil)
+
 	path := fmt.Sprintf("/projects/%d/dashboards", projectID)
 
 	req, err := d.client.newRequest(ctx, "GET", path, nil)
@@ -52,10 +55,25 @@ func (d *DashboardsService) List(ctx context.Context, projectID int) (*Dashboard
 	return &response, nil
 }
 
+// ListPager returns a pager over the dashboards for a project.
+func (d *DashboardsService) ListPager(projectID int) *Pager[Dashboard] {
+	return newPager(d.client, Operation{Name: "Dashboards.List", ProjectID: projectID}, func(ctx context.Context) (*DashboardListResponse, error) {
+		return d.List(ctx, projectID)
+	})
+}
+
+// All returns an iterator over all dashboards for a project,
+// fetching additional pages as needed.
+func (d *DashboardsService) All(ctx context.Context, projectID int) iter.Seq2[Dashboard, error] {
+	return d.ListPager(projectID).All(ctx)
+}
+
 // Get returns a single dashboard by ID.
 //
 // GET /v2/projects/{projectID}/dashboards/{dashboardID}
 func (d *DashboardsService) Get(ctx context.Context, projectID int, dashboardID string) (*Dashboard, error) {
+	ctx = withOperation(ctx, "Dashboards.Get", projectID, dashboardID)
+
 	path := fmt.Sprintf("/projects/%d/dashboards/%s", projectID, dashboardID)
 
 	req, err := d.client.newRequest(ctx, "GET", path, nil)
@@ -75,6 +93,8 @@ func (d *DashboardsService) Get(ctx context.Context, projectID int, dashboardID
 //
 // POST /v2/projects/{projectID}/dashboards
 func (d *DashboardsService) Create(ctx context.Context, projectID int, dashboardReq DashboardRequest) (*Dashboard, error) {
+	ctx = withOperation(ctx, "Dashboards.Create", projectID, nil)
+
 	body := map[string]interface{}{
 		"dashboard": dashboardReq,
 	}
@@ -97,6 +117,8 @@ func (d *DashboardsService) Create(ctx context.Context, projectID int, dashboard
 //
 // PUT /v2/projects/{projectID}/dashboards/{dashboardID}
 func (d *DashboardsService) Update(ctx context.Context, projectID int, dashboardID string, dashboardReq DashboardRequest) (*UpdateResult, error) {
+	ctx = withOperation(ctx, "Dashboards.Update", projectID, dashboardID)
+
 	body := map[string]interface{}{
 		"dashboard": dashboardReq,
 	}
@@ -121,6 +143,8 @@ func (d *DashboardsService) Update(ctx context.Context, projectID int, dashboard
 //
 // DELETE /v2/projects/{projectID}/dashboards/{dashboardID}
 func (d *DashboardsService) Delete(ctx context.Context, projectID int, dashboardID string) (*DeleteResult, error) {
+	ctx = withOperation(ctx, "Dashboards.Delete", projectID, dashboardID)
+
 	path := fmt.Sprintf("/projects/%d/dashboards/%s", projectID, dashboardID)
 
 	req, err := d.client.newRequest(ctx, "DELETE", path, nil)
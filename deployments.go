@@ -14,6 +14,8 @@ type DeploymentsService struct {
 
 // List retrieves all deployments for a project with optional filtering
 func (s *DeploymentsService) List(ctx context.Context, projectID int, options DeploymentListOptions) ([]Deployment, error) {
+	ctx = withOperation(ctx, "Deployments.List", projectID, nil)
+
 	path := fmt.Sprintf("/projects/%d/deploys", projectID)
 
 	// Build query parameters
@@ -53,6 +55,8 @@ func (s *DeploymentsService) List(ctx context.Context, projectID int, options De
 
 // Get retrieves a single deployment by ID
 func (s *DeploymentsService) Get(ctx context.Context, projectID, deploymentID int) (*Deployment, error) {
+	ctx = withOperation(ctx, "Deployments.Get", projectID, deploymentID)
+
 	path := fmt.Sprintf("/projects/%d/deploys/%d", projectID, deploymentID)
 
 	req, err := s.client.newRequest(ctx, "GET", path, nil)
@@ -70,6 +74,8 @@ func (s *DeploymentsService) Get(ctx context.Context, projectID, deploymentID in
 
 // Delete deletes a deployment
 func (s *DeploymentsService) Delete(ctx context.Context, projectID, deploymentID int) error {
+	ctx = withOperation(ctx, "Deployments.Delete", projectID, deploymentID)
+
 	path := fmt.Sprintf("/projects/%d/deploys/%d", projectID, deploymentID)
 
 	req, err := s.client.newRequest(ctx, "DELETE", path, nil)
@@ -79,3 +85,27 @@ func (s *DeploymentsService) Delete(ctx context.Context, projectID, deploymentID
 
 	return s.client.do(ctx, req, nil)
 }
+
+// Create records a deployment. Deployments are recorded through the Reporting
+// API, which identifies the project by the API key set with WithAPIKey.
+//
+// Honeybadger API docs: https://docs.honeybadger.io/api/reporting-deployments/
+//
+// POST /v1/deploys
+func (s *DeploymentsService) Create(ctx context.Context, params DeploymentParams) error {
+	ctx = withOperation(ctx, "Deployments.Create", 0, nil)
+
+	reqBody := struct {
+		Deploy DeploymentParams `json:"deploy"`
+	}{
+		Deploy: params,
+	}
+
+	req, err := s.client.newReportingRequest(ctx, "POST", "/deploys", reqBody)
+	if err != nil {
+		return err
+	}
+
+	// Create returns {"status": "OK"}.
+	return s.client.do(ctx, req, nil)
+}
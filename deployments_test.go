@@ -2,6 +2,7 @@ package honeybadgerapi
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -203,3 +204,41 @@ func TestDeploymentsDelete(t *testing.T) {
 		t.Fatalf("Delete() error = %v", err)
 	}
 }
+
+func TestDeploymentsCreate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("expected POST method, got %s", r.Method)
+		}
+		if r.URL.Path != "/v1/deploys" {
+			t.Errorf("expected path /v1/deploys, got %s", r.URL.Path)
+		}
+		if r.Header.Get("X-API-Key") != "project-key" {
+			t.Errorf("expected X-API-Key project-key, got %s", r.Header.Get("X-API-Key"))
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		expected := `{"deploy":{"environment":"production","revision":"abc123","repository":"git@github.com:acme/shop.git","local_username":"ci"}}`
+		if string(body) != expected {
+			t.Errorf("expected body %s, got %s", expected, body)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"status": "OK"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient().
+		WithReportingURL(server.URL).
+		WithAPIKey("project-key")
+
+	err := client.Deployments.Create(context.Background(), DeploymentParams{
+		Environment:   "production",
+		Revision:      "abc123",
+		Repository:    "git@github.com:acme/shop.git",
+		LocalUsername: "ci",
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+}
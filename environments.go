@@ -12,6 +12,8 @@ type EnvironmentsService struct {
 
 // List retrieves all environments for a project
 func (s *EnvironmentsService) List(ctx context.Context, projectID int) ([]Environment, error) {
+	ctx = withOperation(ctx, "Environments.List", projectID, nil)
+
 	path := fmt.Sprintf("/projects/%d/environments", projectID)
 
 	req, err := s.client.newRequest(ctx, "GET", path, nil)
@@ -29,6 +31,8 @@ func (s *EnvironmentsService) List(ctx context.Context, projectID int) ([]Enviro
 
 // Get retrieves a single environment by ID
 func (s *EnvironmentsService) Get(ctx context.Context, projectID, environmentID int) (*Environment, error) {
+	ctx = withOperation(ctx, "Environments.Get", projectID, environmentID)
+
 	path := fmt.Sprintf("/projects/%d/environments/%d", projectID, environmentID)
 
 	req, err := s.client.newRequest(ctx, "GET", path, nil)
@@ -46,6 +50,8 @@ func (s *EnvironmentsService) Get(ctx context.Context, projectID, environmentID
 
 // Create creates a new environment
 func (s *EnvironmentsService) Create(ctx context.Context, projectID int, params EnvironmentParams) (*Environment, error) {
+	ctx = withOperation(ctx, "Environments.Create", projectID, nil)
+
 	path := fmt.Sprintf("/projects/%d/environments", projectID)
 
 	reqBody := EnvironmentRequest{Environment: params}
@@ -65,6 +71,8 @@ func (s *EnvironmentsService) Create(ctx context.Context, projectID int, params
 
 // Update updates an existing environment
 func (s *EnvironmentsService) Update(ctx context.Context, projectID, environmentID int, params EnvironmentParams) error {
+	ctx = withOperation(ctx, "Environments.Update", projectID, environmentID)
+
 	path := fmt.Sprintf("/projects/%d/environments/%d", projectID, environmentID)
 
 	reqBody := EnvironmentRequest{Environment: params}
@@ -80,6 +88,8 @@ func (s *EnvironmentsService) Update(ctx context.Context, projectID, environment
 
 // Delete deletes an environment
 func (s *EnvironmentsService) Delete(ctx context.Context, projectID, environmentID int) error {
+	ctx = withOperation(ctx, "Environments.Delete", projectID, environmentID)
+
 	path := fmt.Sprintf("/projects/%d/environments/%d", projectID, environmentID)
 
 	req, err := s.client.newRequest(ctx, "DELETE", path, nil)
@@ -2,21 +2,134 @@ package honeybadgerapi
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
+	"strings"
+)
+
+// Sentinel errors for classifying API failures with errors.Is
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrValidation   = errors.New("validation failed")
+	ErrServer       = errors.New("server error")
 )
 
 type APIError struct {
-	StatusCode int         `json:"status_code"`
-	Message    string      `json:"message"`
-	Body       interface{} `json:"body,omitempty"`
+	StatusCode int             `json:"status_code"`
+	Message    string          `json:"message"`
+	Body       interface{}     `json:"body,omitempty"`
+	Validation ValidationError `json:"validation,omitempty"` // Set for 422 responses
 }
 
 func (e *APIError) Error() string {
 	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Message)
 }
 
+// Is reports whether the error matches one of the sentinel errors based on its status code
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrValidation:
+		return e.StatusCode == http.StatusUnprocessableEntity
+	case ErrServer:
+		return e.StatusCode >= 500
+	}
+	return false
+}
+
+// As lets errors.As extract the ValidationError from a 422 response
+func (e *APIError) As(target interface{}) bool {
+	if ve, ok := target.(*ValidationError); ok && e.Validation != nil {
+		*ve = e.Validation
+		return true
+	}
+	return false
+}
+
+// ValidationError holds field-level validation messages from a 422 response,
+// keyed by field name. Messages not tied to a field are stored under "base".
+type ValidationError map[string][]string
+
+func (v ValidationError) Error() string {
+	fields := make([]string, 0, len(v))
+	for field := range v {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	parts := make([]string, 0, len(fields))
+	for _, field := range fields {
+		msg := strings.Join(v[field], ", ")
+		if field != "base" {
+			msg = field + " " + msg
+		}
+		parts = append(parts, msg)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Is reports whether target is ErrValidation
+func (v ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// parseValidationError builds a ValidationError from a decoded 422 response body.
+// It accepts {"errors": "..."}, {"errors": ["..."]} and {"errors": {"field": ["..."]}}.
+func parseValidationError(body interface{}) ValidationError {
+	bodyMap, ok := body.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	ve := ValidationError{}
+	switch errs := bodyMap["errors"].(type) {
+	case string:
+		ve["base"] = []string{errs}
+	case []interface{}:
+		ve["base"] = stringsFromJSON(errs)
+	case map[string]interface{}:
+		for field, msgs := range errs {
+			switch m := msgs.(type) {
+			case string:
+				ve[field] = []string{m}
+			case []interface{}:
+				ve[field] = stringsFromJSON(m)
+			}
+		}
+	}
+
+	if len(ve) == 0 {
+		return nil
+	}
+	return ve
+}
+
+// stringsFromJSON converts a decoded JSON array into strings
+func stringsFromJSON(values []interface{}) []string {
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			result = append(result, s)
+		} else {
+			result = append(result, fmt.Sprint(v))
+		}
+	}
+	return result
+}
+
 func WrapError(resp *http.Response, err error) error {
 	if err != nil {
 		return fmt.Errorf("HTTP request failed: %w", err)
@@ -42,6 +155,13 @@ func WrapError(resp *http.Response, err error) error {
 						apiErr.Message = msg
 					}
 				}
+
+				if resp.StatusCode == http.StatusUnprocessableEntity {
+					apiErr.Validation = parseValidationError(jsonBody)
+					if apiErr.Message == resp.Status && apiErr.Validation != nil {
+						apiErr.Message = apiErr.Validation.Error()
+					}
+				}
 			} else {
 				// If not JSON, store as string
 				apiErr.Body = string(body)
@@ -0,0 +1,184 @@
+package honeybadgerapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIError_Is(t *testing.T) {
+	sentinels := []error{ErrNotFound, ErrUnauthorized, ErrForbidden, ErrRateLimited, ErrValidation, ErrServer}
+
+	tests := []struct {
+		statusCode int
+		want       error
+	}{
+		{400, nil},
+		{401, ErrUnauthorized},
+		{403, ErrForbidden},
+		{404, ErrNotFound},
+		{422, ErrValidation},
+		{429, ErrRateLimited},
+		{500, ErrServer},
+		{503, ErrServer},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("HTTP %d", tt.statusCode), func(t *testing.T) {
+			err := fmt.Errorf("wrapped: %w", &APIError{StatusCode: tt.statusCode})
+			for _, sentinel := range sentinels {
+				if got := errors.Is(err, sentinel); got != (sentinel == tt.want) {
+					t.Errorf("errors.Is(%d, %v) = %v", tt.statusCode, sentinel, got)
+				}
+			}
+		})
+	}
+}
+
+func TestValidationError_Error(t *testing.T) {
+	ve := ValidationError{
+		"url":  {"is invalid"},
+		"base": {"Plan limit reached"},
+		"name": {"can't be blank", "is too short"},
+	}
+
+	expected := "Plan limit reached; name can't be blank, is too short; url is invalid"
+	if ve.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, ve.Error())
+	}
+	if !errors.Is(ve, ErrValidation) {
+		t.Error("expected ValidationError to match ErrValidation")
+	}
+}
+
+func TestParseValidationError(t *testing.T) {
+	tests := []struct {
+		name string
+		body interface{}
+		want ValidationError
+	}{
+		{
+			name: "string errors",
+			body: map[string]interface{}{"errors": "Name has already been taken"},
+			want: ValidationError{"base": {"Name has already been taken"}},
+		},
+		{
+			name: "array errors",
+			body: map[string]interface{}{"errors": []interface{}{"Name can't be blank", "URL is invalid"}},
+			want: ValidationError{"base": {"Name can't be blank", "URL is invalid"}},
+		},
+		{
+			name: "field errors",
+			body: map[string]interface{}{"errors": map[string]interface{}{
+				"name":          []interface{}{"can't be blank"},
+				"report_period": "is not a valid duration",
+			}},
+			want: ValidationError{"name": {"can't be blank"}, "report_period": {"is not a valid duration"}},
+		},
+		{
+			name: "no errors key",
+			body: map[string]interface{}{"message": "Unprocessable"},
+			want: nil,
+		},
+		{
+			name: "not an object",
+			body: "Unprocessable",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseValidationError(tt.body)
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+			for field, msgs := range tt.want {
+				if fmt.Sprint(got[field]) != fmt.Sprint(msgs) {
+					t.Errorf("expected %s messages %v, got %v", field, msgs, got[field])
+				}
+			}
+		})
+	}
+}
+
+func TestValidationError_FromCreateCalls(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"errors": {"name": ["can't be blank"], "url": ["is invalid"]}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token")
+
+	calls := map[string]func() error{
+		"Projects.Create": func() error {
+			_, err := client.Projects.Create(context.Background(), "abc", ProjectRequest{})
+			return err
+		},
+		"CheckIns.Create": func() error {
+			_, err := client.CheckIns.Create(context.Background(), 123, CheckInParams{})
+			return err
+		},
+		"Uptime.Create": func() error {
+			_, err := client.Uptime.Create(context.Background(), 123, SiteParams{})
+			return err
+		},
+	}
+
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			err := call()
+			if !errors.Is(err, ErrValidation) {
+				t.Fatalf("expected ErrValidation, got %v", err)
+			}
+
+			var ve ValidationError
+			if !errors.As(err, &ve) {
+				t.Fatalf("expected ValidationError, got %T", err)
+			}
+			if len(ve["name"]) != 1 || ve["name"][0] != "can't be blank" {
+				t.Errorf("expected name error \"can't be blank\", got %v", ve["name"])
+			}
+			if len(ve["url"]) != 1 || ve["url"][0] != "is invalid" {
+				t.Errorf("expected url error \"is invalid\", got %v", ve["url"])
+			}
+
+			apiErr, ok := err.(*APIError)
+			if !ok {
+				t.Fatalf("expected APIError, got %T", err)
+			}
+			if apiErr.Message != "name can't be blank; url is invalid" {
+				t.Errorf("expected message from validation errors, got %s", apiErr.Message)
+			}
+		})
+	}
+}
+
+func TestValidationError_NotSetForOtherStatuses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errors": "Project not found"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token")
+
+	_, err := client.Projects.Get(context.Background(), 1)
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	var ve ValidationError
+	if errors.As(err, &ve) {
+		t.Errorf("expected no ValidationError for 404, got %v", ve)
+	}
+}
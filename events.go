@@ -0,0 +1,336 @@
+package honeybadgerapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// EventsService sends structured events to Honeybadger Insights. Events are
+// sent to the Reporting API and authenticate with the project API key set by
+// WithAPIKey; query them with InsightsService.
+type EventsService struct {
+	client *Client
+}
+
+// Event is a single structured event. A "ts" timestamp is added when the
+// event is sent if it has none.
+type Event map[string]interface{}
+
+// Defaults for EventBatcherOptions
+const (
+	DefaultEventBatchSize     = 100
+	DefaultEventFlushInterval = time.Second
+	DefaultEventQueueSize     = 1000
+)
+
+var (
+	// ErrEventQueueFull is returned by EventBatcher.Add when the queue is full and the batcher does not block
+	ErrEventQueueFull = errors.New("event queue is full")
+
+	// ErrBatcherClosed is returned by EventBatcher.Add after Close
+	ErrBatcherClosed = errors.New("event batcher is closed")
+)
+
+// Send sends events synchronously in a single request. Events are encoded as
+// newline-delimited JSON.
+//
+// Honeybadger API docs: https://docs.honeybadger.io/api/reporting-events/
+//
+// POST /v1/events
+func (e *EventsService) Send(ctx context.Context, events ...Event) error {
+	ctx = withOperation(ctx, "Events.Send", 0, nil)
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	data, err := encodeEvents(events, time.Now())
+	if err != nil {
+		return err
+	}
+
+	req, err := e.client.newReportingRawRequest(ctx, "POST", "/events", "application/x-ndjson", bytes.NewReader(data), events)
+	if err != nil {
+		return err
+	}
+
+	// Events returns 201 Created with an empty body.
+	return e.client.do(ctx, req, nil)
+}
+
+// encodeEvents encodes events as newline-delimited JSON, stamping events without a "ts" with now
+func encodeEvents(events []Event, now time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	ts := now.UTC().Format(time.RFC3339Nano)
+	for i, event := range events {
+		if _, ok := event["ts"]; !ok {
+			stamped := make(Event, len(event)+1)
+			for k, v := range event {
+				stamped[k] = v
+			}
+			stamped["ts"] = ts
+			event = stamped
+		}
+		line, err := json.Marshal(event)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal event %d: %w", i, err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// EventBatcherOptions configures an EventBatcher
+type EventBatcherOptions struct {
+	BatchSize     int                           // Events per request; defaults to DefaultEventBatchSize
+	FlushInterval time.Duration                 // Maximum time an event waits before it is sent; defaults to DefaultEventFlushInterval
+	QueueSize     int                           // Events buffered before Add blocks or drops; defaults to DefaultEventQueueSize
+	Block         bool                          // Block Add while the queue is full instead of dropping the event
+	Sync          bool                          // Send full batches from Add in the caller's goroutine, with no background worker
+	OnError       func(err error, lost []Event) // Called when a background send fails; may be nil
+}
+
+// EventBatcher buffers events and sends them in batches. In the default
+// asynchronous mode a background worker sends a batch when it is full or when
+// FlushInterval elapses, and memory is bounded by QueueSize. In Sync mode,
+// suited to CLIs, Add sends full batches itself and returns their errors.
+//
+// Call Close on shutdown to send buffered events.
+type EventBatcher struct {
+	events  *EventsService
+	options EventBatcherOptions
+
+	// Add and Flush hold mu for reading, so Close can wait for them with the
+	// write lock before sending the remaining events. Close sets closed and
+	// closes done first, which makes waiting calls return early.
+	mu      sync.RWMutex
+	closed  atomic.Bool
+	done    chan struct{}
+	dropped atomic.Uint64
+
+	// Async mode
+	queue chan Event
+	flush chan chan error
+	stop  chan chan error
+
+	// Sync mode
+	syncMu  sync.Mutex
+	pending []Event
+}
+
+// NewBatcher returns an EventBatcher that sends events through this service
+func (e *EventsService) NewBatcher(options EventBatcherOptions) *EventBatcher {
+	if options.BatchSize < 1 {
+		options.BatchSize = DefaultEventBatchSize
+	}
+	if options.FlushInterval <= 0 {
+		options.FlushInterval = DefaultEventFlushInterval
+	}
+	if options.QueueSize < 1 {
+		options.QueueSize = DefaultEventQueueSize
+	}
+
+	b := &EventBatcher{events: e, options: options, done: make(chan struct{})}
+	if !options.Sync {
+		b.queue = make(chan Event, options.QueueSize)
+		b.flush = make(chan chan error)
+		b.stop = make(chan chan error)
+		go b.run()
+	}
+	return b
+}
+
+// Add queues an event. When the queue is full, Add drops the event and
+// returns ErrEventQueueFull, or blocks until there is room if Block is set.
+// In Sync mode, Add sends the batch once it is full and returns any error.
+func (b *EventBatcher) Add(ctx context.Context, event Event) error {
+	if b.closed.Load() {
+		return ErrBatcherClosed
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed.Load() {
+		return ErrBatcherClosed
+	}
+
+	if b.options.Sync {
+		b.syncMu.Lock()
+		defer b.syncMu.Unlock()
+
+		b.pending = append(b.pending, event)
+		if len(b.pending) < b.options.BatchSize {
+			return nil
+		}
+		return b.sendPending(ctx)
+	}
+
+	if b.options.Block {
+		select {
+		case b.queue <- event:
+			return nil
+		case <-b.done:
+			return ErrBatcherClosed
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	select {
+	case b.queue <- event:
+		return nil
+	default:
+		b.dropped.Add(1)
+		return ErrEventQueueFull
+	}
+}
+
+// Dropped returns the number of events dropped because the queue was full
+func (b *EventBatcher) Dropped() uint64 {
+	return b.dropped.Load()
+}
+
+// Flush sends all buffered events and waits for the sends to finish. It
+// returns the errors of the sends it waited for.
+func (b *EventBatcher) Flush(ctx context.Context) error {
+	if b.options.Sync {
+		b.syncMu.Lock()
+		defer b.syncMu.Unlock()
+		return b.sendPending(ctx)
+	}
+
+	// Holding the read lock keeps the worker running until the flush is done
+	if b.closed.Load() {
+		return nil
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed.Load() {
+		return nil
+	}
+
+	// Once Close starts, it sends the buffered events itself
+	reply := make(chan error, 1)
+	select {
+	case b.flush <- reply:
+	case <-b.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case err := <-reply:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close sends all buffered events and stops the batcher. Events added after
+// Close are rejected with ErrBatcherClosed. If ctx expires first, Close
+// returns its error and the remaining events are sent in the background.
+func (b *EventBatcher) Close(ctx context.Context) error {
+	if !b.closed.CompareAndSwap(false, true) {
+		return nil
+	}
+	close(b.done)
+
+	// Waiting for Add and Flush calls in progress happens in the background,
+	// so Close returns when ctx expires even if one of them is still sending.
+	// The remaining events are always sent, and the worker then exits.
+	reply := make(chan error, 1)
+	go func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		if b.options.Sync {
+			b.syncMu.Lock()
+			defer b.syncMu.Unlock()
+			reply <- b.sendPending(context.Background())
+			return
+		}
+		b.stop <- reply
+	}()
+
+	select {
+	case err := <-reply:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// sendPending sends the pending events in Sync mode. Callers must hold syncMu.
+func (b *EventBatcher) sendPending(ctx context.Context) error {
+	var errs []error
+	for len(b.pending) > 0 {
+		n := min(len(b.pending), b.options.BatchSize)
+		batch := b.pending[:n]
+		if err := b.events.Send(ctx, batch...); err != nil {
+			errs = append(errs, err)
+		}
+		b.pending = b.pending[n:]
+	}
+	b.pending = nil
+	return errors.Join(errs...)
+}
+
+// run is the background worker in async mode
+func (b *EventBatcher) run() {
+	ticker := time.NewTicker(b.options.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Event, 0, b.options.BatchSize)
+	send := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		err := b.events.Send(context.Background(), batch...)
+		if err != nil && b.options.OnError != nil {
+			b.options.OnError(err, batch)
+		}
+		batch = make([]Event, 0, b.options.BatchSize)
+		return err
+	}
+
+	// drain sends everything currently queued
+	drain := func() error {
+		var errs []error
+		for {
+			select {
+			case event := <-b.queue:
+				batch = append(batch, event)
+				if len(batch) >= b.options.BatchSize {
+					errs = append(errs, send())
+				}
+			default:
+				errs = append(errs, send())
+				return errors.Join(errs...)
+			}
+		}
+	}
+
+	for {
+		select {
+		case event := <-b.queue:
+			batch = append(batch, event)
+			if len(batch) >= b.options.BatchSize {
+				_ = send()
+			}
+		case <-ticker.C:
+			_ = send()
+		case reply := <-b.flush:
+			reply <- drain()
+		case reply := <-b.stop:
+			reply <- drain()
+			return
+		}
+	}
+}
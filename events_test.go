@@ -0,0 +1,303 @@
+package honeybadgerapi
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// eventsServer records the events received in each request
+type eventsServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	batches  [][]Event
+	received chan struct{} // Signaled after each request is recorded
+	release  chan struct{} // If set, requests wait for it before responding
+	status   int
+}
+
+func newEventsServer(t *testing.T) *eventsServer {
+	t.Helper()
+	s := &eventsServer{received: make(chan struct{}, 100), status: http.StatusCreated}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/v1/events" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Content-Type") != "application/x-ndjson" {
+			t.Errorf("expected NDJSON content type, got %s", r.Header.Get("Content-Type"))
+		}
+		if r.Header.Get("X-API-Key") != "project-key" {
+			t.Errorf("expected X-API-Key project-key, got %s", r.Header.Get("X-API-Key"))
+		}
+
+		var batch []Event
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			var event Event
+			if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+				t.Errorf("failed to decode event line %q: %v", scanner.Text(), err)
+			}
+			batch = append(batch, event)
+		}
+
+		s.mu.Lock()
+		s.batches = append(s.batches, batch)
+		status := s.status
+		s.mu.Unlock()
+		s.received <- struct{}{}
+
+		if s.release != nil {
+			<-s.release
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *eventsServer) batchSizes() []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var sizes []int
+	for _, batch := range s.batches {
+		sizes = append(sizes, len(batch))
+	}
+	return sizes
+}
+
+func newEventsClient(s *eventsServer) *Client {
+	return NewClient().
+		WithReportingURL(s.URL).
+		WithAPIKey("project-key")
+}
+
+func TestSendEvents(t *testing.T) {
+	server := newEventsServer(t)
+	client := newEventsClient(server)
+
+	err := client.Events.Send(context.Background(),
+		Event{"event_type": "signup", "plan": "pro"},
+		Event{"event_type": "login", "ts": "2024-01-02T03:04:05Z"},
+	)
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if len(server.batches) != 1 || len(server.batches[0]) != 2 {
+		t.Fatalf("expected one request with 2 events, got %v", server.batchSizes())
+	}
+	first, second := server.batches[0][0], server.batches[0][1]
+	if first["plan"] != "pro" {
+		t.Errorf("expected plan pro, got %v", first["plan"])
+	}
+	if _, err := time.Parse(time.RFC3339Nano, first["ts"].(string)); err != nil {
+		t.Errorf("expected a ts to be added, got %v", first["ts"])
+	}
+	if second["ts"] != "2024-01-02T03:04:05Z" {
+		t.Errorf("expected existing ts to be kept, got %v", second["ts"])
+	}
+}
+
+func TestSendEvents_Error(t *testing.T) {
+	server := newEventsServer(t)
+	server.status = http.StatusForbidden
+	client := newEventsClient(server)
+
+	err := client.Events.Send(context.Background(), Event{"event_type": "signup"})
+	if !errors.Is(err, ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+
+	if err := NewClient().Events.Send(context.Background(), Event{}); !errors.Is(err, ErrMissingAPIKey) {
+		t.Errorf("expected ErrMissingAPIKey, got %v", err)
+	}
+}
+
+func TestEventBatcher_BatchesAndClose(t *testing.T) {
+	server := newEventsServer(t)
+	batcher := newEventsClient(server).Events.NewBatcher(EventBatcherOptions{BatchSize: 2, FlushInterval: time.Hour})
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		if err := batcher.Add(ctx, Event{"n": i}); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+	if err := batcher.Close(ctx); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	total := 0
+	for _, size := range server.batchSizes() {
+		if size > 2 {
+			t.Errorf("expected batches of at most 2 events, got %d", size)
+		}
+		total += size
+	}
+	if total != 5 {
+		t.Errorf("expected 5 events to be sent, got %d", total)
+	}
+
+	if err := batcher.Add(ctx, Event{}); !errors.Is(err, ErrBatcherClosed) {
+		t.Errorf("expected ErrBatcherClosed, got %v", err)
+	}
+}
+
+func TestEventBatcher_FlushInterval(t *testing.T) {
+	server := newEventsServer(t)
+	batcher := newEventsClient(server).Events.NewBatcher(EventBatcherOptions{FlushInterval: 10 * time.Millisecond})
+	defer batcher.Close(context.Background())
+
+	if err := batcher.Add(context.Background(), Event{"event_type": "tick"}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	select {
+	case <-server.received:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the event to be sent after the flush interval")
+	}
+}
+
+func TestEventBatcher_Flush(t *testing.T) {
+	server := newEventsServer(t)
+	batcher := newEventsClient(server).Events.NewBatcher(EventBatcherOptions{FlushInterval: time.Hour})
+	defer batcher.Close(context.Background())
+
+	_ = batcher.Add(context.Background(), Event{"n": 1})
+	_ = batcher.Add(context.Background(), Event{"n": 2})
+	if err := batcher.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if sizes := server.batchSizes(); len(sizes) != 1 || sizes[0] != 2 {
+		t.Errorf("expected one batch of 2 events, got %v", sizes)
+	}
+}
+
+func TestEventBatcher_QueueFull(t *testing.T) {
+	server := newEventsServer(t)
+	server.release = make(chan struct{})
+	var lost []Event
+	batcher := newEventsClient(server).Events.NewBatcher(EventBatcherOptions{
+		BatchSize:     1,
+		QueueSize:     1,
+		FlushInterval: time.Hour,
+		OnError:       func(err error, events []Event) { lost = append(lost, events...) },
+	})
+	ctx := context.Background()
+
+	// The worker takes the first event and blocks sending it
+	_ = batcher.Add(ctx, Event{"n": 1})
+	<-server.received
+
+	if err := batcher.Add(ctx, Event{"n": 2}); err != nil {
+		t.Fatalf("expected the second event to be queued, got %v", err)
+	}
+	if err := batcher.Add(ctx, Event{"n": 3}); !errors.Is(err, ErrEventQueueFull) {
+		t.Errorf("expected ErrEventQueueFull, got %v", err)
+	}
+	if batcher.Dropped() != 1 {
+		t.Errorf("expected 1 dropped event, got %d", batcher.Dropped())
+	}
+
+	// Blocking adds wait for room until the context expires
+	blocking := newEventsClient(server).Events.NewBatcher(EventBatcherOptions{BatchSize: 1, QueueSize: 1, FlushInterval: time.Hour, Block: true})
+	_ = blocking.Add(ctx, Event{"n": 1})
+	<-server.received
+	_ = blocking.Add(ctx, Event{"n": 2})
+	timeout, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if err := blocking.Add(timeout, Event{"n": 3}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected blocking add to time out, got %v", err)
+	}
+
+	close(server.release)
+	if err := batcher.Close(ctx); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+	if err := blocking.Close(ctx); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+	if len(lost) != 0 {
+		t.Errorf("expected no failed sends, got %v", lost)
+	}
+}
+
+func TestEventBatcher_CloseDoesNotWaitForBlockedAdd(t *testing.T) {
+	server := newEventsServer(t)
+	server.release = make(chan struct{})
+	batcher := newEventsClient(server).Events.NewBatcher(EventBatcherOptions{BatchSize: 1, QueueSize: 1, FlushInterval: time.Hour, Block: true})
+	ctx := context.Background()
+
+	// The worker blocks sending the first event and the second fills the queue
+	_ = batcher.Add(ctx, Event{"n": 1})
+	<-server.received
+	_ = batcher.Add(ctx, Event{"n": 2})
+
+	blocked := make(chan error, 1)
+	go func() { blocked <- batcher.Add(ctx, Event{"n": 3}) }()
+	time.Sleep(20 * time.Millisecond)
+
+	timeout, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := batcher.Close(timeout); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected Close to time out while the worker is sending, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected Close to return when its context expired, took %s", elapsed)
+	}
+
+	select {
+	case err := <-blocked:
+		if !errors.Is(err, ErrBatcherClosed) {
+			t.Errorf("expected the blocked add to get ErrBatcherClosed, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("expected the blocked add to return once Close started")
+	}
+
+	// The queued event is still sent once the worker is free
+	close(server.release)
+	<-server.received
+	if sizes := server.batchSizes(); len(sizes) != 2 {
+		t.Errorf("expected 2 batches, got %v", sizes)
+	}
+}
+
+func TestEventBatcher_Sync(t *testing.T) {
+	server := newEventsServer(t)
+	batcher := newEventsClient(server).Events.NewBatcher(EventBatcherOptions{BatchSize: 2, Sync: true})
+	ctx := context.Background()
+
+	_ = batcher.Add(ctx, Event{"n": 1})
+	if len(server.batchSizes()) != 0 {
+		t.Fatal("expected no request before the batch is full")
+	}
+	if err := batcher.Add(ctx, Event{"n": 2}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if sizes := server.batchSizes(); len(sizes) != 1 || sizes[0] != 2 {
+		t.Fatalf("expected the full batch to be sent by Add, got %v", sizes)
+	}
+
+	_ = batcher.Add(ctx, Event{"n": 3})
+	if err := batcher.Close(ctx); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if sizes := server.batchSizes(); len(sizes) != 2 || sizes[1] != 1 {
+		t.Errorf("expected the remaining event to be sent by Close, got %v", sizes)
+	}
+
+	server.status = http.StatusInternalServerError
+	failing := newEventsClient(server).Events.NewBatcher(EventBatcherOptions{BatchSize: 1, Sync: true})
+	if err := failing.Add(ctx, Event{"n": 4}); !errors.Is(err, ErrServer) {
+		t.Errorf("expected ErrServer from a synchronous add, got %v", err)
+	}
+}
@@ -0,0 +1,252 @@
+package honeybadgerapi
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// faultQueryKey matches search keys such as class or context.user_id
+var faultQueryKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z0-9_]+)*$`)
+
+// FaultQueryTerm is a single term of a fault search query, e.g. -is:resolved
+// or environment:production
+type FaultQueryTerm struct {
+	Negate bool
+	Key    string // Empty for free-text terms
+	Value  string // Unescaped value
+}
+
+// String renders the term in Honeybadger's search syntax, quoting the value if needed
+func (t FaultQueryTerm) String() string {
+	var b strings.Builder
+	if t.Negate {
+		b.WriteByte('-')
+	}
+	if t.Key != "" {
+		b.WriteString(t.Key)
+		b.WriteByte(':')
+	}
+	b.WriteString(quoteSearchValue(t.Value))
+	return b.String()
+}
+
+// quoteSearchValue wraps values containing whitespace, quotes, colons or a
+// leading dash in double quotes, escaping embedded quotes and backslashes
+func quoteSearchValue(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \t\n\r\":\\") && !strings.HasPrefix(value, "-") {
+		return value
+	}
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range value {
+		if r == '"' || r == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// FaultQueryBuilder builds a fault search query for FaultListOptions.Query and
+// FaultListAffectedUsersOptions.Query. Terms are combined with AND:
+//
+//	q := hbapi.FaultQuery().Class("ActiveRecord::RecordNotFound").Environment("production").Unresolved()
+//
+// The zero value is an empty query. Methods append terms and return the
+// builder so calls can be chained. The first invalid key is reported by
+// Build and by the requests the query is used in.
+type FaultQueryBuilder struct {
+	terms []FaultQueryTerm
+	err   error
+}
+
+// FaultQuery returns an empty fault search query
+func FaultQuery() *FaultQueryBuilder {
+	return &FaultQueryBuilder{}
+}
+
+// Where adds a key:value term, e.g. Where("component", "orders"). The key
+// must be an identifier, optionally dotted, e.g. context.user_id.
+func (q *FaultQueryBuilder) Where(key, value string) *FaultQueryBuilder {
+	if key != "" && !faultQueryKey.MatchString(key) {
+		if q.err == nil {
+			q.err = fmt.Errorf("invalid fault query key %q", key)
+		}
+		return q
+	}
+	q.terms = append(q.terms, FaultQueryTerm{Key: key, Value: value})
+	return q
+}
+
+// Text adds a free-text term matched against the fault class and message
+func (q *FaultQueryBuilder) Text(text string) *FaultQueryBuilder {
+	return q.Where("", text)
+}
+
+// Class matches faults with the given error class
+func (q *FaultQueryBuilder) Class(class string) *FaultQueryBuilder {
+	return q.Where("class", class)
+}
+
+// Environment matches faults that occurred in the given environment
+func (q *FaultQueryBuilder) Environment(environment string) *FaultQueryBuilder {
+	return q.Where("environment", environment)
+}
+
+// Tag matches faults with the given tag
+func (q *FaultQueryBuilder) Tag(tag string) *FaultQueryBuilder {
+	return q.Where("tag", tag)
+}
+
+// AssignedTo matches faults assigned to the user with the given email address
+func (q *FaultQueryBuilder) AssignedTo(email string) *FaultQueryBuilder {
+	return q.Where("assignee", email)
+}
+
+// Resolved matches resolved faults
+func (q *FaultQueryBuilder) Resolved() *FaultQueryBuilder {
+	return q.Where("is", "resolved")
+}
+
+// Unresolved matches faults that are not resolved
+func (q *FaultQueryBuilder) Unresolved() *FaultQueryBuilder {
+	return q.Not(FaultQuery().Resolved())
+}
+
+// Ignored matches ignored faults
+func (q *FaultQueryBuilder) Ignored() *FaultQueryBuilder {
+	return q.Where("is", "ignored")
+}
+
+// Assigned matches faults assigned to anyone
+func (q *FaultQueryBuilder) Assigned() *FaultQueryBuilder {
+	return q.Where("is", "assigned")
+}
+
+// Not adds the terms of another query, negated. The search syntax has no
+// grouping, so each term is negated on its own: Not(FaultQuery().Class("A").Tag("b"))
+// excludes faults of class A and faults tagged b.
+func (q *FaultQueryBuilder) Not(other *FaultQueryBuilder) *FaultQueryBuilder {
+	if other == nil {
+		return q
+	}
+	if other.err != nil && q.err == nil {
+		q.err = other.err
+	}
+	for _, term := range other.terms {
+		term.Negate = !term.Negate
+		q.terms = append(q.terms, term)
+	}
+	return q
+}
+
+// Terms returns a copy of the query's terms
+func (q *FaultQueryBuilder) Terms() []FaultQueryTerm {
+	if q == nil {
+		return nil
+	}
+	return append([]FaultQueryTerm(nil), q.terms...)
+}
+
+// Build renders the query in Honeybadger's search syntax, or returns the
+// first invalid key
+func (q *FaultQueryBuilder) Build() (string, error) {
+	if q == nil {
+		return "", nil
+	}
+	if q.err != nil {
+		return "", q.err
+	}
+	return q.String(), nil
+}
+
+// String renders the query in Honeybadger's search syntax. It does not report
+// invalid keys; use Build for that.
+func (q *FaultQueryBuilder) String() string {
+	if q == nil {
+		return ""
+	}
+	parts := make([]string, len(q.terms))
+	for i, term := range q.terms {
+		parts[i] = term.String()
+	}
+	return strings.Join(parts, " ")
+}
+
+// ParseFaultQuery parses a search string into a query builder so it can be
+// inspected or extended. Rendering the result with String produces an
+// equivalent query, normalized to the builder's quoting.
+func ParseFaultQuery(s string) (*FaultQueryBuilder, error) {
+	q := FaultQuery()
+	var token strings.Builder
+	var term FaultQueryTerm
+	inToken, quoted, escaped := false, false, false
+
+	flush := func() {
+		if inToken && (term.Key != "" || token.Len() > 0) {
+			term.Value = token.String()
+			q.terms = append(q.terms, term)
+		}
+		token.Reset()
+		term = FaultQueryTerm{}
+		inToken = false
+	}
+
+	for _, r := range s {
+		switch {
+		case escaped:
+			token.WriteRune(r)
+			escaped = false
+		case quoted && r == '\\':
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+			inToken = true
+		case quoted:
+			token.WriteRune(r)
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			flush()
+		case r == '-' && !inToken:
+			term.Negate = !term.Negate
+			inToken = true
+		case r == ':' && term.Key == "" && token.Len() > 0:
+			term.Key = strings.ToLower(token.String())
+			token.Reset()
+		default:
+			token.WriteRune(r)
+			inToken = true
+		}
+	}
+	if quoted || escaped {
+		return nil, fmt.Errorf("unterminated quote in fault query %q", s)
+	}
+	flush()
+
+	return q, nil
+}
+
+// query returns the search string for the options, combining Q and Query
+func (o FaultListOptions) query() (string, error) {
+	return joinQuery(o.Q, o.Query)
+}
+
+// query returns the search string for the options, combining Q and Query
+func (o FaultListAffectedUsersOptions) query() (string, error) {
+	return joinQuery(o.Q, o.Query)
+}
+
+func joinQuery(q string, query *FaultQueryBuilder) (string, error) {
+	built, err := query.Build()
+	switch {
+	case err != nil:
+		return "", err
+	case q == "":
+		return built, nil
+	case built == "":
+		return q, nil
+	default:
+		return q + " " + built, nil
+	}
+}
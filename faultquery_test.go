@@ -0,0 +1,176 @@
+package honeybadgerapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFaultQuery_String(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    *FaultQueryBuilder
+		expected string
+	}{
+		{
+			name:     "chained filters",
+			query:    FaultQuery().Class("Net::ReadTimeout").Environment("production").Unresolved().Tag("billing"),
+			expected: `class:"Net::ReadTimeout" environment:production -is:resolved tag:billing`,
+		},
+		{
+			name:     "assignee and free text",
+			query:    FaultQuery().AssignedTo("dev@example.com").Text("connection reset"),
+			expected: `assignee:dev@example.com "connection reset"`,
+		},
+		{
+			name:     "escaped quotes and backslashes",
+			query:    FaultQuery().Text(`say "hi" \o/`),
+			expected: `"say \"hi\" \\o/"`,
+		},
+		{
+			name:     "leading dash is quoted",
+			query:    FaultQuery().Text("-1"),
+			expected: `"-1"`,
+		},
+		{
+			name:     "not",
+			query:    FaultQuery().Ignored().Not(FaultQuery().Environment("test").Unresolved()),
+			expected: `is:ignored -environment:test is:resolved`,
+		},
+		{
+			name:     "empty",
+			query:    FaultQuery(),
+			expected: ``,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.query.String(); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseFaultQuery(t *testing.T) {
+	q, err := ParseFaultQuery(`-is:resolved  Class:"Net::ReadTimeout" "say \"hi\"" tag:a:b`)
+	if err != nil {
+		t.Fatalf("ParseFaultQuery() error = %v", err)
+	}
+
+	expected := []FaultQueryTerm{
+		{Negate: true, Key: "is", Value: "resolved"},
+		{Key: "class", Value: "Net::ReadTimeout"},
+		{Value: `say "hi"`},
+		{Key: "tag", Value: "a:b"},
+	}
+	terms := q.Terms()
+	if len(terms) != len(expected) {
+		t.Fatalf("expected %d terms, got %d: %+v", len(expected), len(terms), terms)
+	}
+	for i := range expected {
+		if terms[i] != expected[i] {
+			t.Errorf("term %d: expected %+v, got %+v", i, expected[i], terms[i])
+		}
+	}
+
+	// Rendering and parsing again gives the same terms
+	again, err := ParseFaultQuery(q.String())
+	if err != nil {
+		t.Fatalf("ParseFaultQuery() error = %v", err)
+	}
+	if again.String() != q.String() {
+		t.Errorf("expected round trip to be stable, got %s and %s", q.String(), again.String())
+	}
+
+	// Parsed queries can be extended
+	if got := q.Environment("production").Terms(); len(got) != 5 {
+		t.Errorf("expected 5 terms after extending, got %d", len(got))
+	}
+}
+
+func TestParseFaultQuery_UnterminatedQuote(t *testing.T) {
+	if _, err := ParseFaultQuery(`class:"Net::ReadTimeout`); err == nil {
+		t.Error("expected error for unterminated quote, got nil")
+	}
+}
+
+func TestFaultQuery_InvalidKey(t *testing.T) {
+	if q, err := FaultQuery().Where("context.user_id", "42").Build(); err != nil || q != "context.user_id:42" {
+		t.Errorf("expected context.user_id:42, got %q (%v)", q, err)
+	}
+
+	for _, key := range []string{"foo bar", "tag:x", "-is", "a.", `"class"`} {
+		q, err := FaultQuery().Class("A").Where(key, "x").Build()
+		if err == nil {
+			t.Errorf("expected an error for key %q, got query %q", key, q)
+		}
+	}
+
+	// Errors carry over through Not
+	if _, err := FaultQuery().Not(FaultQuery().Where("foo bar", "x")).Build(); err == nil {
+		t.Error("expected an error for a negated invalid key")
+	}
+
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	client := NewClient().WithBaseURL(server.URL).WithAuthToken("test-token")
+	if _, err := client.Faults.List(context.Background(), 123, FaultListOptions{Query: FaultQuery().Where("foo bar", "x")}); err == nil {
+		t.Error("expected List to reject an invalid key")
+	}
+	if requests != 0 {
+		t.Errorf("expected no requests, got %d", requests)
+	}
+}
+
+func TestFaultListOptions_Query(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get("q"))
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v2/projects/123/faults":
+			_, _ = w.Write([]byte(`{"results": [], "links": {}}`))
+		case "/v2/projects/123/faults/summary":
+			_, _ = w.Write([]byte(`{"total": 0, "environments": []}`))
+		default:
+			_, _ = w.Write([]byte(`[]`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token")
+	ctx := context.Background()
+	query := FaultQuery().Class("Net::ReadTimeout").Unresolved()
+
+	if _, err := client.Faults.List(ctx, 123, FaultListOptions{Query: query}); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if _, err := client.Faults.GetCounts(ctx, 123, FaultListOptions{Q: "environment:production", Query: query}); err != nil {
+		t.Fatalf("GetCounts() error = %v", err)
+	}
+	if _, err := client.Faults.ListAffectedUsers(ctx, 123, 456, FaultListAffectedUsersOptions{Query: FaultQuery().Text("a b")}); err != nil {
+		t.Fatalf("ListAffectedUsers() error = %v", err)
+	}
+
+	expected := []string{
+		`class:"Net::ReadTimeout" -is:resolved`,
+		`environment:production class:"Net::ReadTimeout" -is:resolved`,
+		`"a b"`,
+	}
+	if len(queries) != len(expected) {
+		t.Fatalf("expected %d requests, got %d", len(expected), len(queries))
+	}
+	for i := range expected {
+		if queries[i] != expected[i] {
+			t.Errorf("request %d: expected q %s, got %s", i, expected[i], queries[i])
+		}
+	}
+}
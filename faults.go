@@ -2,7 +2,9 @@ package honeybadgerapi
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"iter"
 	"net/url"
 	"strconv"
 	"time"
@@ -15,13 +17,14 @@ type FaultsService struct {
 
 // FaultListOptions represents options for listing faults
 type FaultListOptions struct {
-	Q              string     // Search string
-	CreatedAfter   *time.Time // Filter faults created after this time
-	OccurredAfter  *time.Time // Filter faults that occurred after this time
-	OccurredBefore *time.Time // Filter faults that occurred before this time
-	Limit          int        // Max 25
-	Order          string     // "recent" or "frequent"
-	Page           int        // Page number for pagination
+	Q              string             // Search string
+	Query          *FaultQueryBuilder // Search built with FaultQuery; combined with Q when both are set
+	CreatedAfter   *time.Time         // Filter faults created after this time
+	OccurredAfter  *time.Time         // Filter faults that occurred after this time
+	OccurredBefore *time.Time         // Filter faults that occurred before this time
+	Limit          int                // Max 25
+	Order          string             // "recent" or "frequent"
+	Page           int                // Page number for pagination
 }
 
 // FaultListResponse represents the API response for listing faults
@@ -33,12 +36,18 @@ type FaultListResponse = ListResponse[Fault]
 //
 // GET /v2/projects/{projectID}/faults
 func (f *FaultsService) List(ctx context.Context, projectID int, options FaultListOptions) (*FaultListResponse, error) {
+	ctx = withOperation(ctx, "Faults.List", projectID, nil)
+
 	path := fmt.Sprintf("/projects/%d/faults", projectID)
 
 	// Build query parameters using url.Values
+	q, err := options.query()
+	if err != nil {
+		return nil, err
+	}
 	params := url.Values{}
-	if options.Q != "" {
-		params.Set("q", options.Q)
+	if q != "" {
+		params.Set("q", q)
 	}
 	if options.CreatedAfter != nil {
 		params.Set("created_after", options.CreatedAfter.Format(time.RFC3339))
@@ -76,12 +85,27 @@ func (f *FaultsService) List(ctx context.Context, projectID int, options FaultLi
 	return &response, nil
 }
 
+// ListPager returns a pager over the faults for a project matching the given options.
+func (f *FaultsService) ListPager(projectID int, options FaultListOptions) *Pager[Fault] {
+	return newPager(f.client, Operation{Name: "Faults.List", ProjectID: projectID}, func(ctx context.Context) (*FaultListResponse, error) {
+		return f.List(ctx, projectID, options)
+	})
+}
+
+// All returns an iterator over all faults for a project matching the given options,
+// fetching additional pages as needed.
+func (f *FaultsService) All(ctx context.Context, projectID int, options FaultListOptions) iter.Seq2[Fault, error] {
+	return f.ListPager(projectID, options).All(ctx)
+}
+
 // Get returns a single fault by ID with full fault details.
 //
 // Honeybadger API docs: https://docs.honeybadger.io/api/faults/#get-a-fault-list-or-fault-details
 //
 // GET /v2/projects/{projectID}/faults/{faultID}
 func (f *FaultsService) Get(ctx context.Context, projectID, faultID int) (*Fault, error) {
+	ctx = withOperation(ctx, "Faults.Get", projectID, faultID)
+
 	path := fmt.Sprintf("/projects/%d/faults/%d", projectID, faultID)
 	req, err := f.client.newRequest(ctx, "GET", path, nil)
 	if err != nil {
@@ -112,6 +136,8 @@ type FaultNoticesResponse = ListResponse[Notice]
 //
 // GET /v2/projects/{projectID}/faults/{faultID}/notices
 func (f *FaultsService) ListNotices(ctx context.Context, projectID, faultID int, options FaultListNoticesOptions) (*FaultNoticesResponse, error) {
+	ctx = withOperation(ctx, "Faults.ListNotices", projectID, faultID)
+
 	path := fmt.Sprintf("/projects/%d/faults/%d/notices", projectID, faultID)
 
 	// Build query parameters using url.Values
@@ -143,6 +169,19 @@ func (f *FaultsService) ListNotices(ctx context.Context, projectID, faultID int,
 	return &response, nil
 }
 
+// ListNoticesPager returns a pager over the notices for a fault matching the given options.
+func (f *FaultsService) ListNoticesPager(projectID, faultID int, options FaultListNoticesOptions) *Pager[Notice] {
+	return newPager(f.client, Operation{Name: "Faults.ListNotices", ProjectID: projectID, ResourceID: strconv.Itoa(faultID)}, func(ctx context.Context) (*FaultNoticesResponse, error) {
+		return f.ListNotices(ctx, projectID, faultID, options)
+	})
+}
+
+// AllNotices returns an iterator over all notices for a fault matching the given options,
+// fetching additional pages as needed.
+func (f *FaultsService) AllNotices(ctx context.Context, projectID, faultID int, options FaultListNoticesOptions) iter.Seq2[Notice, error] {
+	return f.ListNoticesPager(projectID, faultID, options).All(ctx)
+}
+
 // FaultAffectedUser represents a user affected by a fault
 type FaultAffectedUser struct {
 	User  string `json:"user"`  // Email or user identifier
@@ -151,7 +190,8 @@ type FaultAffectedUser struct {
 
 // FaultListAffectedUsersOptions represents options for listing affected users for a fault
 type FaultListAffectedUsersOptions struct {
-	Q string // Search string
+	Q     string             // Search string
+	Query *FaultQueryBuilder // Search built with FaultQuery; combined with Q when both are set
 }
 
 // ListAffectedUsers returns a list of users affected by a specific fault.
@@ -160,11 +200,17 @@ type FaultListAffectedUsersOptions struct {
 //
 // GET /v2/projects/{projectID}/faults/{faultID}/affected_users
 func (f *FaultsService) ListAffectedUsers(ctx context.Context, projectID, faultID int, options FaultListAffectedUsersOptions) ([]FaultAffectedUser, error) {
+	ctx = withOperation(ctx, "Faults.ListAffectedUsers", projectID, faultID)
+
 	path := fmt.Sprintf("/projects/%d/faults/%d/affected_users", projectID, faultID)
 
 	// Build query parameters if search provided
-	if options.Q != "" {
-		path += fmt.Sprintf("?q=%s", url.QueryEscape(options.Q))
+	q, err := options.query()
+	if err != nil {
+		return nil, err
+	}
+	if q != "" {
+		path += fmt.Sprintf("?q=%s", url.QueryEscape(q))
 	}
 
 	req, err := f.client.newRequest(ctx, "GET", path, nil)
@@ -200,12 +246,18 @@ type FaultCounts struct {
 //
 // GET /v2/projects/{projectID}/faults/summary
 func (f *FaultsService) GetCounts(ctx context.Context, projectID int, options FaultListOptions) (*FaultCounts, error) {
+	ctx = withOperation(ctx, "Faults.GetCounts", projectID, nil)
+
 	path := fmt.Sprintf("/projects/%d/faults/summary", projectID)
 
 	// Build query parameters using url.Values (reuse same filtering options as List)
+	q, err := options.query()
+	if err != nil {
+		return nil, err
+	}
 	params := url.Values{}
-	if options.Q != "" {
-		params.Set("q", options.Q)
+	if q != "" {
+		params.Set("q", q)
 	}
 	if options.CreatedAfter != nil {
 		params.Set("created_after", options.CreatedAfter.Format(time.RFC3339))
@@ -233,3 +285,239 @@ func (f *FaultsService) GetCounts(ctx context.Context, projectID int, options Fa
 
 	return &counts, nil
 }
+
+// FaultUpdateParams represents the fault attributes to change. Nil fields are left unchanged.
+type FaultUpdateParams struct {
+	Resolved   *bool    // Mark the fault resolved or unresolved
+	Ignored    *bool    // Mark the fault ignored or not ignored
+	AssigneeID *int     // Assign the fault to this user
+	Unassign   bool     // Clear the assignee; ignored if AssigneeID is set
+	Tags       []string // Replace the fault's tags; nil leaves them unchanged
+}
+
+// MarshalJSON encodes only the attributes being changed, sending a null
+// assignee_id when unassigning
+func (p FaultUpdateParams) MarshalJSON() ([]byte, error) {
+	body := map[string]interface{}{}
+	if p.Resolved != nil {
+		body["resolved"] = *p.Resolved
+	}
+	if p.Ignored != nil {
+		body["ignored"] = *p.Ignored
+	}
+	if p.AssigneeID != nil {
+		body["assignee_id"] = *p.AssigneeID
+	} else if p.Unassign {
+		body["assignee_id"] = nil
+	}
+	if p.Tags != nil {
+		body["tags"] = p.Tags
+	}
+	return json.Marshal(body)
+}
+
+// Update changes the state of a fault: resolved, ignored, assignee and tags.
+//
+// Honeybadger API docs: https://docs.honeybadger.io/api/faults/#update-a-fault
+//
+// PUT /v2/projects/{projectID}/faults/{faultID}
+func (f *FaultsService) Update(ctx context.Context, projectID, faultID int, params FaultUpdateParams) error {
+	ctx = withOperation(ctx, "Faults.Update", projectID, faultID)
+
+	body := map[string]interface{}{
+		"fault": params,
+	}
+
+	path := fmt.Sprintf("/projects/%d/faults/%d", projectID, faultID)
+	req, err := f.client.newRequest(ctx, "PUT", path, body)
+	if err != nil {
+		return err
+	}
+
+	// Update returns 204 No Content.
+	return f.client.do(ctx, req, nil)
+}
+
+// Resolve marks a fault as resolved
+func (f *FaultsService) Resolve(ctx context.Context, projectID, faultID int) error {
+	resolved := true
+	return f.Update(ctx, projectID, faultID, FaultUpdateParams{Resolved: &resolved})
+}
+
+// Unresolve marks a resolved fault as unresolved
+func (f *FaultsService) Unresolve(ctx context.Context, projectID, faultID int) error {
+	resolved := false
+	return f.Update(ctx, projectID, faultID, FaultUpdateParams{Resolved: &resolved})
+}
+
+// Ignore marks a fault as ignored, so new occurrences do not send notifications
+func (f *FaultsService) Ignore(ctx context.Context, projectID, faultID int) error {
+	ignored := true
+	return f.Update(ctx, projectID, faultID, FaultUpdateParams{Ignored: &ignored})
+}
+
+// Unignore marks an ignored fault as no longer ignored
+func (f *FaultsService) Unignore(ctx context.Context, projectID, faultID int) error {
+	ignored := false
+	return f.Update(ctx, projectID, faultID, FaultUpdateParams{Ignored: &ignored})
+}
+
+// Assign assigns a fault to a user
+func (f *FaultsService) Assign(ctx context.Context, projectID, faultID, userID int) error {
+	return f.Update(ctx, projectID, faultID, FaultUpdateParams{AssigneeID: &userID})
+}
+
+// Unassign removes the assignee from a fault
+func (f *FaultsService) Unassign(ctx context.Context, projectID, faultID int) error {
+	return f.Update(ctx, projectID, faultID, FaultUpdateParams{Unassign: true})
+}
+
+// Delete permanently deletes a fault and its notices.
+//
+// Honeybadger API docs: https://docs.honeybadger.io/api/faults/
+//
+// DELETE /v2/projects/{projectID}/faults/{faultID}
+func (f *FaultsService) Delete(ctx context.Context, projectID, faultID int) error {
+	ctx = withOperation(ctx, "Faults.Delete", projectID, faultID)
+
+	path := fmt.Sprintf("/projects/%d/faults/%d", projectID, faultID)
+	req, err := f.client.newRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	return f.client.do(ctx, req, nil)
+}
+
+// Merge merges duplicate faults into the target fault. The duplicates' notices
+// are moved to the target and the duplicates are removed.
+//
+// Honeybadger API docs: https://docs.honeybadger.io/api/faults/
+//
+// POST /v2/projects/{projectID}/faults/{faultID}/merge
+func (f *FaultsService) Merge(ctx context.Context, projectID, targetFaultID int, duplicateIDs ...int) error {
+	ctx = withOperation(ctx, "Faults.Merge", projectID, targetFaultID)
+
+	if len(duplicateIDs) == 0 {
+		return fmt.Errorf("no faults to merge into fault %d", targetFaultID)
+	}
+	for _, id := range duplicateIDs {
+		if id == targetFaultID {
+			return fmt.Errorf("cannot merge fault %d into itself", id)
+		}
+	}
+
+	body := map[string]interface{}{
+		"fault_ids": duplicateIDs,
+	}
+
+	path := fmt.Sprintf("/projects/%d/faults/%d/merge", projectID, targetFaultID)
+	req, err := f.client.newRequest(ctx, "POST", path, body)
+	if err != nil {
+		return err
+	}
+
+	return f.client.do(ctx, req, nil)
+}
+
+// PauseWindow is a period to pause fault notifications for
+type PauseWindow string
+
+const (
+	PauseHour PauseWindow = "hour"
+	PauseDay  PauseWindow = "day"
+	PauseWeek PauseWindow = "week"
+)
+
+// PauseCount is a number of occurrences to pause fault notifications for
+type PauseCount int
+
+const (
+	Pause10Times   PauseCount = 10
+	Pause100Times  PauseCount = 100
+	Pause1000Times PauseCount = 1000
+)
+
+// FaultPauseOptions selects how long to pause notifications for a fault.
+// Set exactly one of Window or Count.
+type FaultPauseOptions struct {
+	Window PauseWindow // Pause for a period of time
+	Count  PauseCount  // Pause until the fault occurs this many more times
+}
+
+// PauseFor returns options that pause notifications for a period of time
+func PauseFor(window PauseWindow) FaultPauseOptions {
+	return FaultPauseOptions{Window: window}
+}
+
+// PauseForCount returns options that pause notifications until the fault occurs count more times
+func PauseForCount(count PauseCount) FaultPauseOptions {
+	return FaultPauseOptions{Count: count}
+}
+
+func (o FaultPauseOptions) validate() error {
+	switch {
+	case o.Window != "" && o.Count != 0:
+		return fmt.Errorf("pause options must set either a window or a count, not both")
+	case o.Window != "":
+		switch o.Window {
+		case PauseHour, PauseDay, PauseWeek:
+			return nil
+		}
+		return fmt.Errorf("invalid pause window %q", o.Window)
+	case o.Count != 0:
+		switch o.Count {
+		case Pause10Times, Pause100Times, Pause1000Times:
+			return nil
+		}
+		return fmt.Errorf("invalid pause count %d", o.Count)
+	}
+	return fmt.Errorf("pause options must set a window or a count")
+}
+
+// MarshalJSON encodes the options as {"time": window} or {"count": n}
+func (o FaultPauseOptions) MarshalJSON() ([]byte, error) {
+	if o.Window != "" {
+		return json.Marshal(map[string]interface{}{"time": o.Window})
+	}
+	return json.Marshal(map[string]interface{}{"count": o.Count})
+}
+
+// PauseNotifications stops notifications for a fault for a period of time or
+// until it occurs a number of times. Occurrences are still recorded.
+//
+// Honeybadger API docs: https://docs.honeybadger.io/api/faults/
+//
+// POST /v2/projects/{projectID}/faults/{faultID}/pause
+func (f *FaultsService) PauseNotifications(ctx context.Context, projectID, faultID int, options FaultPauseOptions) error {
+	ctx = withOperation(ctx, "Faults.PauseNotifications", projectID, faultID)
+
+	if err := options.validate(); err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("/projects/%d/faults/%d/pause", projectID, faultID)
+	req, err := f.client.newRequest(ctx, "POST", path, options)
+	if err != nil {
+		return err
+	}
+
+	return f.client.do(ctx, req, nil)
+}
+
+// UnpauseNotifications resumes notifications for a paused fault.
+//
+// Honeybadger API docs: https://docs.honeybadger.io/api/faults/
+//
+// POST /v2/projects/{projectID}/faults/{faultID}/unpause
+func (f *FaultsService) UnpauseNotifications(ctx context.Context, projectID, faultID int) error {
+	ctx = withOperation(ctx, "Faults.UnpauseNotifications", projectID, faultID)
+
+	path := fmt.Sprintf("/projects/%d/faults/%d/unpause", projectID, faultID)
+	req, err := f.client.newRequest(ctx, "POST", path, nil)
+	if err != nil {
+		return err
+	}
+
+	return f.client.do(ctx, req, nil)
+}
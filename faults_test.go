@@ -2,6 +2,7 @@ package honeybadgerapi
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -950,3 +951,231 @@ func TestGetFaultCounts_InvalidResource(t *testing.T) {
 		t.Errorf("expected error message 'Invalid resource', got %s", apiErr.Message)
 	}
 }
+
+func TestUpdateFault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("expected PUT method, got %s", r.Method)
+		}
+		if r.URL.Path != "/v2/projects/123/faults/456" {
+			t.Errorf("expected path /v2/projects/123/faults/456, got %s", r.URL.Path)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		expected := `{"fault":{"assignee_id":7,"ignored":false,"resolved":true}}`
+		if string(body) != expected {
+			t.Errorf("expected body %s, got %s", expected, body)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token")
+
+	resolved, ignored, assigneeID := true, false, 7
+	err := client.Faults.Update(context.Background(), 123, 456, FaultUpdateParams{
+		Resolved:   &resolved,
+		Ignored:    &ignored,
+		AssigneeID: &assigneeID,
+	})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+}
+
+func TestUpdateFault_Convenience(t *testing.T) {
+	tests := []struct {
+		name     string
+		call     func(*FaultsService) error
+		expected string
+	}{
+		{"Resolve", func(f *FaultsService) error { return f.Resolve(context.Background(), 123, 456) }, `{"fault":{"resolved":true}}`},
+		{"Unresolve", func(f *FaultsService) error { return f.Unresolve(context.Background(), 123, 456) }, `{"fault":{"resolved":false}}`},
+		{"Ignore", func(f *FaultsService) error { return f.Ignore(context.Background(), 123, 456) }, `{"fault":{"ignored":true}}`},
+		{"Unignore", func(f *FaultsService) error { return f.Unignore(context.Background(), 123, 456) }, `{"fault":{"ignored":false}}`},
+		{"Assign", func(f *FaultsService) error { return f.Assign(context.Background(), 123, 456, 1) }, `{"fault":{"assignee_id":1}}`},
+		{"Unassign", func(f *FaultsService) error { return f.Unassign(context.Background(), 123, 456) }, `{"fault":{"assignee_id":null}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if string(body) != tt.expected {
+					t.Errorf("expected body %s, got %s", tt.expected, body)
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			client := NewClient().
+				WithBaseURL(server.URL).
+				WithAuthToken("test-token")
+
+			if err := tt.call(client.Faults); err != nil {
+				t.Fatalf("%s() error = %v", tt.name, err)
+			}
+		})
+	}
+}
+
+func TestUpdateFault_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errors": "Fault not found"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token")
+
+	err := client.Faults.Resolve(context.Background(), 123, 999)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		t.Fatalf("expected APIError, got %T", err)
+	}
+
+	if apiErr.StatusCode != 404 {
+		t.Errorf("expected status code 404, got %d", apiErr.StatusCode)
+	}
+}
+
+func TestDeleteFault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("expected DELETE method, got %s", r.Method)
+		}
+		if r.URL.Path != "/v2/projects/123/faults/456" {
+			t.Errorf("expected path /v2/projects/123/faults/456, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token")
+
+	if err := client.Faults.Delete(context.Background(), 123, 456); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+}
+
+func TestMergeFaults(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("expected POST method, got %s", r.Method)
+		}
+		if r.URL.Path != "/v2/projects/123/faults/456/merge" {
+			t.Errorf("expected path /v2/projects/123/faults/456/merge, got %s", r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"fault_ids":[457,458]}` {
+			t.Errorf("unexpected body %s", body)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token")
+
+	if err := client.Faults.Merge(context.Background(), 123, 456, 457, 458); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+}
+
+func TestMergeFaults_Invalid(t *testing.T) {
+	client := NewClient().WithAuthToken("test-token")
+
+	if err := client.Faults.Merge(context.Background(), 123, 456); err == nil {
+		t.Error("expected error when no duplicates are given, got nil")
+	}
+	if err := client.Faults.Merge(context.Background(), 123, 456, 456); err == nil {
+		t.Error("expected error when merging a fault into itself, got nil")
+	}
+}
+
+func TestPauseNotifications(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  FaultPauseOptions
+		expected string
+	}{
+		{"window", PauseFor(PauseDay), `{"time":"day"}`},
+		{"count", PauseForCount(Pause100Times), `{"count":100}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "POST" {
+					t.Errorf("expected POST method, got %s", r.Method)
+				}
+				if r.URL.Path != "/v2/projects/123/faults/456/pause" {
+					t.Errorf("expected path /v2/projects/123/faults/456/pause, got %s", r.URL.Path)
+				}
+				body, _ := io.ReadAll(r.Body)
+				if string(body) != tt.expected {
+					t.Errorf("expected body %s, got %s", tt.expected, body)
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			client := NewClient().
+				WithBaseURL(server.URL).
+				WithAuthToken("test-token")
+
+			if err := client.Faults.PauseNotifications(context.Background(), 123, 456, tt.options); err != nil {
+				t.Fatalf("PauseNotifications() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestPauseNotifications_InvalidOptions(t *testing.T) {
+	client := NewClient().WithAuthToken("test-token")
+
+	for _, options := range []FaultPauseOptions{
+		{},
+		{Window: "month"},
+		{Count: -1},
+		{Count: 50},
+		{Window: PauseHour, Count: 10},
+	} {
+		if err := client.Faults.PauseNotifications(context.Background(), 123, 456, options); err == nil {
+			t.Errorf("expected error for options %+v, got nil", options)
+		}
+	}
+}
+
+func TestUnpauseNotifications(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("expected POST method, got %s", r.Method)
+		}
+		if r.URL.Path != "/v2/projects/123/faults/456/unpause" {
+			t.Errorf("expected path /v2/projects/123/faults/456/unpause, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token")
+
+	if err := client.Faults.UnpauseNotifications(context.Background(), 123, 456); err != nil {
+		t.Fatalf("UnpauseNotifications() error = %v", err)
+	}
+}
@@ -0,0 +1,266 @@
+package hbapitest
+
+import (
+	"fmt"
+
+	hbapi "github.com/honeybadger-io/api-go"
+)
+
+// AddProject seeds a project under the given account and returns it as stored.
+// A zero ID is replaced with a generated one and a missing token is generated.
+func (s *Server) AddProject(accountID string, project hbapi.Project) hbapi.Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if project.ID == 0 {
+		project.ID = s.newID()
+	}
+	if project.CreatedAt.IsZero() {
+		project.CreatedAt = now()
+	}
+	if project.Token == "" {
+		project.Token = fmt.Sprintf("hbp_%d", project.ID)
+	}
+	if project.Environments == nil {
+		project.Environments = []string{}
+	}
+
+	s.projects[project.ID] = &project
+	s.accountOf[project.ID] = accountID
+	return project
+}
+
+// AddFault seeds a fault for a project and returns it as stored
+func (s *Server) AddFault(projectID int, fault hbapi.Fault) hbapi.Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if fault.ID == 0 {
+		fault.ID = s.newID()
+	}
+	fault.ProjectID = projectID
+	if fault.CreatedAt.IsZero() {
+		fault.CreatedAt = now()
+	}
+	if fault.Tags == nil {
+		fault.Tags = []string{}
+	}
+	if fault.URL == "" {
+		fault.URL = fmt.Sprintf("https://app.honeybadger.io/projects/%d/faults/%d", projectID, fault.ID)
+	}
+
+	s.faults[projectID] = append(s.faults[projectID], &fault)
+	if p, ok := s.projects[projectID]; ok {
+		p.FaultCount++
+		if !fault.Resolved {
+			p.UnresolvedFaultCount++
+		}
+	}
+	return fault
+}
+
+// AddNotice seeds a notice for a fault and returns it as stored.
+// The fault's notice count and last notice time are updated.
+func (s *Server) AddNotice(projectID, faultID int, notice hbapi.Notice) hbapi.Notice {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if notice.ID == "" {
+		notice.ID = fmt.Sprintf("notice-%d", s.newID())
+	}
+	notice.FaultID = faultID
+	if notice.CreatedAt.IsZero() {
+		notice.CreatedAt = now()
+	}
+
+	s.notices[faultID] = append(s.notices[faultID], notice)
+	if f := s.findFault(projectID, faultID); f != nil {
+		f.NoticesCount++
+		if f.LastNoticeAt == nil || notice.CreatedAt.After(*f.LastNoticeAt) {
+			createdAt := notice.CreatedAt
+			f.LastNoticeAt = &createdAt
+		}
+	}
+	return notice
+}
+
+// AddComment seeds a comment on a fault and returns it as stored
+func (s *Server) AddComment(faultID int, comment hbapi.Comment) hbapi.Comment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if comment.ID == 0 {
+		comment.ID = s.newID()
+	}
+	comment.FaultID = faultID
+	if comment.CreatedAt.IsZero() {
+		comment.CreatedAt = now()
+	}
+
+	s.comments[faultID] = append(s.comments[faultID], &comment)
+	return comment
+}
+
+// AddCheckIn seeds a check-in for a project and returns it as stored
+func (s *Server) AddCheckIn(projectID int, checkIn hbapi.CheckIn) hbapi.CheckIn {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.fillCheckIn(&checkIn)
+	s.checkIns[projectID] = append(s.checkIns[projectID], &checkIn)
+	return checkIn
+}
+
+// AddSite seeds an uptime site for a project and returns it as stored
+func (s *Server) AddSite(projectID int, site hbapi.Site) hbapi.Site {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if site.ID == "" {
+		site.ID = fmt.Sprintf("site-%d", s.newID())
+	}
+	if site.State == "" {
+		site.State = "up"
+	}
+
+	s.sites[projectID] = append(s.sites[projectID], &site)
+	return site
+}
+
+// AddEnvironment seeds an environment for a project and returns it as stored
+func (s *Server) AddEnvironment(projectID int, env hbapi.Environment) hbapi.Environment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if env.ID == 0 {
+		env.ID = s.newID()
+	}
+	env.ProjectID = projectID
+	if env.CreatedAt.IsZero() {
+		env.CreatedAt = now()
+	}
+	if env.UpdatedAt.IsZero() {
+		env.UpdatedAt = env.CreatedAt
+	}
+
+	s.environments[projectID] = append(s.environments[projectID], &env)
+	return env
+}
+
+// AddTeam seeds a team under the given account and returns it as stored
+func (s *Server) AddTeam(accountID string, team hbapi.Team) hbapi.Team {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if team.ID == 0 {
+		team.ID = s.newID()
+	}
+	if team.CreatedAt.IsZero() {
+		team.CreatedAt = now()
+	}
+
+	s.teams[team.ID] = &team
+	s.teamAccounts[team.ID] = accountID
+	return team
+}
+
+// AddDashboard seeds an Insights dashboard for a project and returns it as stored
+func (s *Server) AddDashboard(projectID int, dashboard hbapi.Dashboard) hbapi.Dashboard {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if dashboard.ID == "" {
+		dashboard.ID = fmt.Sprintf("dashboard-%d", s.newID())
+	}
+	dashboard.ProjectID = projectID
+	if dashboard.CreatedAt.IsZero() {
+		dashboard.CreatedAt = now()
+	}
+	if dashboard.UpdatedAt.IsZero() {
+		dashboard.UpdatedAt = dashboard.CreatedAt
+	}
+	if dashboard.Widgets == nil {
+		dashboard.Widgets = []map[string]interface{}{}
+	}
+
+	s.dashboards[projectID] = append(s.dashboards[projectID], &dashboard)
+	return dashboard
+}
+
+// AddStatusPage seeds a status page under the given account and returns it as stored
+func (s *Server) AddStatusPage(accountID string, page hbapi.StatusPage) hbapi.StatusPage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if page.ID == "" {
+		page.ID = fmt.Sprintf("status-page-%d", s.newID())
+	}
+	page.AccountID = accountID
+	if page.CreatedAt.IsZero() {
+		page.CreatedAt = now()
+	}
+	if page.Sites == nil {
+		page.Sites = []hbapi.StatusPageSite{}
+	}
+	if page.CheckIns == nil {
+		page.CheckIns = []hbapi.StatusPageCheckIn{}
+	}
+
+	s.statusPages[accountID] = append(s.statusPages[accountID], &page)
+	return page
+}
+
+// Fault returns the current state of a fault, for asserting on changes made through the client
+func (s *Server) Fault(projectID, faultID int) (hbapi.Fault, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if f := s.findFault(projectID, faultID); f != nil {
+		return *f, true
+	}
+	return hbapi.Fault{}, false
+}
+
+// Paused reports whether notifications for a fault have been paused
+func (s *Server) Paused(faultID int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.paused[faultID]
+}
+
+// Project returns the current state of a project
+func (s *Server) Project(projectID int) (hbapi.Project, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, ok := s.projects[projectID]; ok {
+		return *p, true
+	}
+	return hbapi.Project{}, false
+}
+
+// findFault returns the stored fault or nil. Callers must hold s.mu.
+func (s *Server) findFault(projectID, faultID int) *hbapi.Fault {
+	for _, f := range s.faults[projectID] {
+		if f.ID == faultID {
+			return f
+		}
+	}
+	return nil
+}
+
+// fillCheckIn sets generated fields on a new check-in. Callers must hold s.mu.
+func (s *Server) fillCheckIn(checkIn *hbapi.CheckIn) {
+	if checkIn.ID == "" {
+		checkIn.ID = fmt.Sprintf("check-in-%d", s.newID())
+	}
+	if checkIn.State == "" {
+		checkIn.State = "pending"
+	}
+	if checkIn.ScheduleType == "" {
+		checkIn.ScheduleType = "simple"
+	}
+	if checkIn.URL == "" {
+		checkIn.URL = fmt.Sprintf("https://api.honeybadger.io/v1/check_in/%s", checkIn.ID)
+	}
+}
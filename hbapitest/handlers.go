@@ -0,0 +1,1289 @@
+package hbapitest
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	hbapi "github.com/honeybadger-io/api-go"
+)
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /v2/projects", s.listProjects)
+	mux.HandleFunc("POST /v2/projects", s.createProject)
+	mux.HandleFunc("GET /v2/projects/{projectID}", s.getProject)
+	mux.HandleFunc("PUT /v2/projects/{projectID}", s.updateProject)
+	mux.HandleFunc("DELETE /v2/projects/{projectID}", s.deleteProject)
+
+	mux.HandleFunc("GET /v2/projects/{projectID}/faults", s.listFaults)
+	mux.HandleFunc("GET /v2/projects/{projectID}/faults/summary", s.faultCounts)
+	mux.HandleFunc("GET /v2/projects/{projectID}/faults/{faultID}", s.getFault)
+	mux.HandleFunc("PUT /v2/projects/{projectID}/faults/{faultID}", s.updateFault)
+	mux.HandleFunc("DELETE /v2/projects/{projectID}/faults/{faultID}", s.deleteFault)
+	mux.HandleFunc("POST /v2/projects/{projectID}/faults/{faultID}/merge", s.mergeFaults)
+	mux.HandleFunc("POST /v2/projects/{projectID}/faults/{faultID}/pause", s.pauseFault)
+	mux.HandleFunc("POST /v2/projects/{projectID}/faults/{faultID}/unpause", s.unpauseFault)
+	mux.HandleFunc("GET /v2/projects/{projectID}/faults/{faultID}/notices", s.listNotices)
+	mux.HandleFunc("GET /v2/projects/{projectID}/faults/{faultID}/affected_users", s.listAffectedUsers)
+
+	mux.HandleFunc("GET /v2/projects/{projectID}/faults/{faultID}/comments", s.listComments)
+	mux.HandleFunc("POST /v2/projects/{projectID}/faults/{faultID}/comments", s.createComment)
+	mux.HandleFunc("GET /v2/projects/{projectID}/faults/{faultID}/comments/{commentID}", s.getComment)
+	mux.HandleFunc("PUT /v2/projects/{projectID}/faults/{faultID}/comments/{commentID}", s.updateComment)
+	mux.HandleFunc("DELETE /v2/projects/{projectID}/faults/{faultID}/comments/{commentID}", s.deleteComment)
+
+	mux.HandleFunc("GET /v2/projects/{projectID}/check_ins", s.listCheckIns)
+	mux.HandleFunc("POST /v2/projects/{projectID}/check_ins", s.createCheckIn)
+	mux.HandleFunc("GET /v2/projects/{projectID}/check_ins/{checkInID}", s.getCheckIn)
+	mux.HandleFunc("PUT /v2/projects/{projectID}/check_ins/{checkInID}", s.updateCheckIn)
+	mux.HandleFunc("DELETE /v2/projects/{projectID}/check_ins/{checkInID}", s.deleteCheckIn)
+
+	mux.HandleFunc("GET /v2/projects/{projectID}/sites", s.listSites)
+	mux.HandleFunc("POST /v2/projects/{projectID}/sites", s.createSite)
+	mux.HandleFunc("GET /v2/projects/{projectID}/sites/{siteID}", s.getSite)
+	mux.HandleFunc("PUT /v2/projects/{projectID}/sites/{siteID}", s.updateSite)
+	mux.HandleFunc("DELETE /v2/projects/{projectID}/sites/{siteID}", s.deleteSite)
+	mux.HandleFunc("GET /v2/projects/{projectID}/sites/{siteID}/outages", s.emptySiteResults)
+	mux.HandleFunc("GET /v2/projects/{projectID}/sites/{siteID}/uptime_checks", s.emptySiteResults)
+
+	mux.HandleFunc("GET /v2/projects/{projectID}/environments", s.listEnvironments)
+	mux.HandleFunc("POST /v2/projects/{projectID}/environments", s.createEnvironment)
+	mux.HandleFunc("GET /v2/projects/{projectID}/environments/{environmentID}", s.getEnvironment)
+	mux.HandleFunc("PUT /v2/projects/{projectID}/environments/{environmentID}", s.updateEnvironment)
+	mux.HandleFunc("DELETE /v2/projects/{projectID}/environments/{environmentID}", s.deleteEnvironment)
+
+	mux.HandleFunc("GET /v2/projects/{projectID}/dashboards", s.listDashboards)
+	mux.HandleFunc("POST /v2/projects/{projectID}/dashboards", s.createDashboard)
+	mux.HandleFunc("GET /v2/projects/{projectID}/dashboards/{dashboardID}", s.getDashboard)
+	mux.HandleFunc("PUT /v2/projects/{projectID}/dashboards/{dashboardID}", s.updateDashboard)
+	mux.HandleFunc("DELETE /v2/projects/{projectID}/dashboards/{dashboardID}", s.deleteDashboard)
+
+	mux.HandleFunc("GET /v2/teams", s.listTeams)
+	mux.HandleFunc("POST /v2/teams", s.createTeam)
+	mux.HandleFunc("GET /v2/teams/{teamID}", s.getTeam)
+	mux.HandleFunc("PUT /v2/teams/{teamID}", s.updateTeam)
+	mux.HandleFunc("DELETE /v2/teams/{teamID}", s.deleteTeam)
+
+	mux.HandleFunc("GET /v2/accounts/{accountID}/status_pages", s.listStatusPages)
+	mux.HandleFunc("POST /v2/accounts/{accountID}/status_pages", s.createStatusPage)
+	mux.HandleFunc("GET /v2/accounts/{accountID}/status_pages/{statusPageID}", s.getStatusPage)
+	mux.HandleFunc("PUT /v2/accounts/{accountID}/status_pages/{statusPageID}", s.updateStatusPage)
+	mux.HandleFunc("DELETE /v2/accounts/{accountID}/status_pages/{statusPageID}", s.deleteStatusPage)
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		notFound(w)
+	})
+
+	return s.intercept(mux)
+}
+
+// project looks up the project named in the path, writing a 404 response if it does not exist.
+// Callers must hold s.mu.
+func (s *Server) project(w http.ResponseWriter, r *http.Request) (*hbapi.Project, bool) {
+	id, ok := intParam(w, r, "projectID")
+	if !ok {
+		return nil, false
+	}
+	p, ok := s.projects[id]
+	if !ok {
+		notFound(w)
+		return nil, false
+	}
+	return p, true
+}
+
+// fault looks up the fault named in the path, writing a 404 response if it does not exist.
+// Callers must hold s.mu.
+func (s *Server) fault(w http.ResponseWriter, r *http.Request) (*hbapi.Fault, bool) {
+	p, ok := s.project(w, r)
+	if !ok {
+		return nil, false
+	}
+	faultID, ok := intParam(w, r, "faultID")
+	if !ok {
+		return nil, false
+	}
+	f := s.findFault(p.ID, faultID)
+	if f == nil {
+		notFound(w)
+		return nil, false
+	}
+	return f, true
+}
+
+// values dereferences stored items for encoding
+func values[T any](items []*T) []T {
+	result := make([]T, 0, len(items))
+	for _, item := range items {
+		result = append(result, *item)
+	}
+	return result
+}
+
+// find returns the index of the first item matching the predicate, or -1
+func find[T any](items []*T, match func(*T) bool) int {
+	for i, item := range items {
+		if match(item) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Projects
+
+func (s *Server) listProjects(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	accountID := r.URL.Query().Get("account_id")
+	ids := make([]int, 0, len(s.projects))
+	for id := range s.projects {
+		if accountID == "" || s.accountOf[id] == accountID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+
+	projects := make([]hbapi.Project, 0, len(ids))
+	for _, id := range ids {
+		projects = append(projects, *s.projects[id])
+	}
+	paginate(s, w, r, projects)
+}
+
+func (s *Server) getProject(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, ok := s.project(w, r); ok {
+		writeJSON(w, http.StatusOK, p)
+	}
+}
+
+type projectBody struct {
+	Project hbapi.ProjectRequest `json:"project"`
+}
+
+func (s *Server) createProject(w http.ResponseWriter, r *http.Request) {
+	var body projectBody
+	if !decode(w, r, &body) {
+		return
+	}
+	if body.Project.Name == "" {
+		writeValidationError(w, map[string][]string{"name": {"can't be blank"}})
+		return
+	}
+
+	p := s.AddProject(r.URL.Query().Get("account_id"), hbapi.Project{
+		Name:   body.Project.Name,
+		Active: true,
+	})
+	writeJSON(w, http.StatusCreated, p)
+}
+
+func (s *Server) updateProject(w http.ResponseWriter, r *http.Request) {
+	var body projectBody
+	if !decode(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.project(w, r)
+	if !ok {
+		return
+	}
+	if body.Project.Name != "" {
+		p.Name = body.Project.Name
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteProject(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.project(w, r)
+	if !ok {
+		return
+	}
+	delete(s.projects, p.ID)
+	delete(s.accountOf, p.ID)
+	delete(s.faults, p.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Faults
+
+// matchingFaults returns the project's faults filtered by the request's search and time parameters.
+// Callers must hold s.mu.
+func (s *Server) matchingFaults(projectID int, r *http.Request) []hbapi.Fault {
+	query := r.URL.Query()
+	search := parseSearch(query.Get("q"))
+
+	var createdAfter, occurredAfter, occurredBefore time.Time
+	if t, err := time.Parse(time.RFC3339, query.Get("created_after")); err == nil {
+		createdAfter = t
+	}
+	if t, err := time.Parse(time.RFC3339, query.Get("occurred_after")); err == nil {
+		occurredAfter = t
+	}
+	if t, err := time.Parse(time.RFC3339, query.Get("occurred_before")); err == nil {
+		occurredBefore = t
+	}
+
+	var faults []hbapi.Fault
+	for _, f := range s.faults[projectID] {
+		if !search.matches(f) {
+			continue
+		}
+		if !createdAfter.IsZero() && !f.CreatedAt.After(createdAfter) {
+			continue
+		}
+		if !occurredAfter.IsZero() && (f.LastNoticeAt == nil || !f.LastNoticeAt.After(occurredAfter)) {
+			continue
+		}
+		if !occurredBefore.IsZero() && (f.LastNoticeAt == nil || !f.LastNoticeAt.Before(occurredBefore)) {
+			continue
+		}
+		faults = append(faults, *f)
+	}
+	return faults
+}
+
+func (s *Server) listFaults(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.project(w, r)
+	if !ok {
+		return
+	}
+
+	faults := s.matchingFaults(p.ID, r)
+	switch r.URL.Query().Get("order") {
+	case "frequent":
+		sort.SliceStable(faults, func(i, j int) bool {
+			return faults[i].NoticesCount > faults[j].NoticesCount
+		})
+	case "recent":
+		sort.SliceStable(faults, func(i, j int) bool {
+			return lastNoticeAt(faults[i]).After(lastNoticeAt(faults[j]))
+		})
+	}
+	paginate(s, w, r, faults)
+}
+
+func lastNoticeAt(f hbapi.Fault) time.Time {
+	if f.LastNoticeAt == nil {
+		return f.CreatedAt
+	}
+	return *f.LastNoticeAt
+}
+
+func (s *Server) getFault(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if f, ok := s.fault(w, r); ok {
+		writeJSON(w, http.StatusOK, f)
+	}
+}
+
+type faultBody struct {
+	Fault map[string]json.RawMessage `json:"fault"`
+}
+
+func (s *Server) updateFault(w http.ResponseWriter, r *http.Request) {
+	var body faultBody
+	if !decode(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, ok := s.fault(w, r)
+	if !ok {
+		return
+	}
+	p := s.projects[f.ProjectID]
+
+	var resolved, ignored *bool
+	var assigneeID *int
+	var tags []string
+	for key, value := range body.Fault {
+		var err error
+		switch key {
+		case "resolved":
+			err = json.Unmarshal(value, &resolved)
+		case "ignored":
+			err = json.Unmarshal(value, &ignored)
+		case "assignee_id":
+			err = json.Unmarshal(value, &assigneeID)
+		case "tags":
+			err = json.Unmarshal(value, &tags)
+		}
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid value for "+key)
+			return
+		}
+	}
+
+	if resolved != nil && *resolved != f.Resolved {
+		f.Resolved = *resolved
+		if f.Resolved {
+			p.UnresolvedFaultCount--
+		} else {
+			p.UnresolvedFaultCount++
+		}
+	}
+	if ignored != nil {
+		f.Ignored = *ignored
+	}
+	if _, ok := body.Fault["assignee_id"]; ok {
+		f.Assignee = nil
+		if assigneeID != nil {
+			f.Assignee = &hbapi.User{ID: *assigneeID}
+		}
+	}
+	if tags != nil {
+		f.Tags = storedTags(tags)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// storedTags applies the server's tag rules to the tags of a fault update:
+// they are split on commas and whitespace, lowercased, and stored sorted
+// without empty tags or duplicates. It does not use hbapi.NormalizeTags so
+// that tests against the fake catch the client drifting from these rules.
+func storedTags(tags []string) []string {
+	seen := map[string]bool{}
+	stored := []string{}
+	for _, value := range tags {
+		for _, tag := range strings.FieldsFunc(value, func(r rune) bool {
+			return r == ',' || unicode.IsSpace(r)
+		}) {
+			tag = strings.ToLower(tag)
+			if !seen[tag] {
+				seen[tag] = true
+				stored = append(stored, tag)
+			}
+		}
+	}
+	sort.Strings(stored)
+	return stored
+}
+
+// removeFault deletes a fault and its notices and comments. Callers must hold s.mu.
+func (s *Server) removeFault(f *hbapi.Fault) {
+	faults := s.faults[f.ProjectID]
+	if i := find(faults, func(other *hbapi.Fault) bool { return other.ID == f.ID }); i >= 0 {
+		s.faults[f.ProjectID] = append(faults[:i], faults[i+1:]...)
+	}
+	delete(s.notices, f.ID)
+	delete(s.comments, f.ID)
+	delete(s.paused, f.ID)
+
+	if p, ok := s.projects[f.ProjectID]; ok {
+		p.FaultCount--
+		if !f.Resolved {
+			p.UnresolvedFaultCount--
+		}
+	}
+}
+
+func (s *Server) deleteFault(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if f, ok := s.fault(w, r); ok {
+		s.removeFault(f)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) mergeFaults(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		FaultIDs []int `json:"fault_ids"`
+	}
+	if !decode(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	target, ok := s.fault(w, r)
+	if !ok {
+		return
+	}
+
+	var duplicates []*hbapi.Fault
+	for _, id := range body.FaultIDs {
+		f := s.findFault(target.ProjectID, id)
+		if f == nil || f.ID == target.ID {
+			writeValidationError(w, map[string][]string{"fault_ids": {"must be other faults in the project"}})
+			return
+		}
+		duplicates = append(duplicates, f)
+	}
+
+	for _, f := range duplicates {
+		for _, n := range s.notices[f.ID] {
+			n.FaultID = target.ID
+			s.notices[target.ID] = append(s.notices[target.ID], n)
+		}
+		target.NoticesCount += f.NoticesCount
+		if f.LastNoticeAt != nil && (target.LastNoticeAt == nil || f.LastNoticeAt.After(*target.LastNoticeAt)) {
+			target.LastNoticeAt = f.LastNoticeAt
+		}
+		s.removeFault(f)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) pauseFault(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Time  string `json:"time"`
+		Count int    `json:"count"`
+	}
+	if !decode(w, r, &body) {
+		return
+	}
+	switch {
+	case body.Time == "" && body.Count == 0:
+		writeValidationError(w, map[string][]string{"base": {"time or count is required"}})
+		return
+	case body.Time != "" && body.Time != "hour" && body.Time != "day" && body.Time != "week":
+		writeValidationError(w, map[string][]string{"time": {"is not included in the list"}})
+		return
+	case body.Count != 0 && body.Count != 10 && body.Count != 100 && body.Count != 1000:
+		writeValidationError(w, map[string][]string{"count": {"is not included in the list"}})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if f, ok := s.fault(w, r); ok {
+		s.paused[f.ID] = true
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) unpauseFault(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if f, ok := s.fault(w, r); ok {
+		delete(s.paused, f.ID)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) faultCounts(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.project(w, r)
+	if !ok {
+		return
+	}
+
+	type group struct {
+		environment       string
+		resolved, ignored bool
+	}
+	counts := map[group]int{}
+	var order []group
+	faults := s.matchingFaults(p.ID, r)
+	for _, f := range faults {
+		g := group{f.Environment, f.Resolved, f.Ignored}
+		if _, seen := counts[g]; !seen {
+			order = append(order, g)
+		}
+		counts[g]++
+	}
+
+	result := hbapi.FaultCounts{Total: len(faults), Environments: []hbapi.FaultCountsEnvironment{}}
+	for _, g := range order {
+		result.Environments = append(result.Environments, hbapi.FaultCountsEnvironment{
+			Environment: g.environment,
+			Resolved:    g.resolved,
+			Ignored:     g.ignored,
+			Count:       counts[g],
+		})
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) listNotices(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, ok := s.fault(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	var notices []hbapi.Notice
+	for _, n := range s.notices[f.ID] {
+		if t, err := time.Parse(time.RFC3339, query.Get("created_after")); err == nil && !n.CreatedAt.After(t) {
+			continue
+		}
+		if t, err := time.Parse(time.RFC3339, query.Get("created_before")); err == nil && !n.CreatedAt.Before(t) {
+			continue
+		}
+		notices = append(notices, n)
+	}
+
+	// Most recent first, as the API returns them
+	sort.SliceStable(notices, func(i, j int) bool {
+		return notices[i].CreatedAt.After(notices[j].CreatedAt)
+	})
+	paginate(s, w, r, notices)
+}
+
+func (s *Server) listAffectedUsers(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, ok := s.fault(w, r)
+	if !ok {
+		return
+	}
+
+	q := strings.ToLower(r.URL.Query().Get("q"))
+	counts := map[string]int{}
+	var order []string
+	for _, n := range s.notices[f.ID] {
+		user := ""
+		if email, ok := n.Request.User["email"].(string); ok {
+			user = email
+		} else if id, ok := n.Request.User["id"]; ok {
+			user = toString(id)
+		}
+		if user == "" || (q != "" && !strings.Contains(strings.ToLower(user), q)) {
+			continue
+		}
+		if _, seen := counts[user]; !seen {
+			order = append(order, user)
+		}
+		counts[user]++
+	}
+
+	users := []hbapi.FaultAffectedUser{}
+	for _, user := range order {
+		users = append(users, hbapi.FaultAffectedUser{User: user, Count: counts[user]})
+	}
+	writeJSON(w, http.StatusOK, users)
+}
+
+func toString(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case int:
+		return strconv.Itoa(v)
+	}
+	return ""
+}
+
+// Comments
+
+type commentBody struct {
+	Comment struct {
+		Body string `json:"body"`
+	} `json:"comment"`
+}
+
+func (s *Server) listComments(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if f, ok := s.fault(w, r); ok {
+		results(w, values(s.comments[f.ID]))
+	}
+}
+
+// comment looks up the comment named in the path. Callers must hold s.mu.
+func (s *Server) comment(w http.ResponseWriter, r *http.Request) (*hbapi.Fault, int, bool) {
+	f, ok := s.fault(w, r)
+	if !ok {
+		return nil, 0, false
+	}
+	commentID, ok := intParam(w, r, "commentID")
+	if !ok {
+		return nil, 0, false
+	}
+	i := find(s.comments[f.ID], func(c *hbapi.Comment) bool { return c.ID == commentID })
+	if i < 0 {
+		notFound(w)
+		return nil, 0, false
+	}
+	return f, i, true
+}
+
+func (s *Server) getComment(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if f, i, ok := s.comment(w, r); ok {
+		writeJSON(w, http.StatusOK, s.comments[f.ID][i])
+	}
+}
+
+func (s *Server) createComment(w http.ResponseWriter, r *http.Request) {
+	var body commentBody
+	if !decode(w, r, &body) {
+		return
+	}
+	if body.Comment.Body == "" {
+		writeValidationError(w, map[string][]string{"body": {"can't be blank"}})
+		return
+	}
+
+	s.mu.Lock()
+	f, ok := s.fault(w, r)
+	if ok {
+		f.CommentsCount++
+	}
+	s.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	c := s.AddComment(f.ID, hbapi.Comment{
+		Event:  "comment",
+		Source: "api",
+		Author: "API",
+		Body:   body.Comment.Body,
+	})
+	writeJSON(w, http.StatusCreated, c)
+}
+
+func (s *Server) updateComment(w http.ResponseWriter, r *http.Request) {
+	var body commentBody
+	if !decode(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if f, i, ok := s.comment(w, r); ok {
+		s.comments[f.ID][i].Body = body.Comment.Body
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) deleteComment(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if f, i, ok := s.comment(w, r); ok {
+		s.comments[f.ID] = append(s.comments[f.ID][:i], s.comments[f.ID][i+1:]...)
+		f.CommentsCount--
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// Check-ins
+
+func (s *Server) listCheckIns(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, ok := s.project(w, r); ok {
+		results(w, values(s.checkIns[p.ID]))
+	}
+}
+
+// checkIn looks up the check-in named in the path. Callers must hold s.mu.
+func (s *Server) checkIn(w http.ResponseWriter, r *http.Request) (*hbapi.Project, int, bool) {
+	p, ok := s.project(w, r)
+	if !ok {
+		return nil, 0, false
+	}
+	id := r.PathValue("checkInID")
+	i := find(s.checkIns[p.ID], func(c *hbapi.CheckIn) bool { return c.ID == id })
+	if i < 0 {
+		notFound(w)
+		return nil, 0, false
+	}
+	return p, i, true
+}
+
+func (s *Server) getCheckIn(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, i, ok := s.checkIn(w, r); ok {
+		writeJSON(w, http.StatusOK, s.checkIns[p.ID][i])
+	}
+}
+
+func applyCheckInParams(c *hbapi.CheckIn, params hbapi.CheckInParams) {
+	if params.Name != "" {
+		c.Name = params.Name
+	}
+	if params.Slug != "" {
+		c.Slug = params.Slug
+	}
+	if params.ScheduleType != "" {
+		c.ScheduleType = params.ScheduleType
+	}
+	if params.ReportPeriod != nil {
+		c.ReportPeriod = params.ReportPeriod
+	}
+	if params.GracePeriod != nil {
+		c.GracePeriod = params.GracePeriod
+	}
+	if params.CronSchedule != nil {
+		c.CronSchedule = params.CronSchedule
+	}
+	if params.CronTimezone != nil {
+		c.CronTimezone = params.CronTimezone
+	}
+}
+
+func (s *Server) createCheckIn(w http.ResponseWriter, r *http.Request) {
+	var body hbapi.CheckInRequest
+	if !decode(w, r, &body) {
+		return
+	}
+
+	errs := map[string][]string{}
+	if body.CheckIn.Name == "" {
+		errs["name"] = []string{"can't be blank"}
+	}
+	if body.CheckIn.ScheduleType == "cron" && body.CheckIn.CronSchedule == nil {
+		errs["cron_schedule"] = []string{"can't be blank"}
+	}
+	if len(errs) > 0 {
+		writeValidationError(w, errs)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.project(w, r)
+	if !ok {
+		return
+	}
+
+	c := &hbapi.CheckIn{}
+	applyCheckInParams(c, body.CheckIn)
+	s.fillCheckIn(c)
+	s.checkIns[p.ID] = append(s.checkIns[p.ID], c)
+	writeJSON(w, http.StatusCreated, c)
+}
+
+func (s *Server) updateCheckIn(w http.ResponseWriter, r *http.Request) {
+	var body hbapi.CheckInRequest
+	if !decode(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, i, ok := s.checkIn(w, r); ok {
+		applyCheckInParams(s.checkIns[p.ID][i], body.CheckIn)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) deleteCheckIn(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, i, ok := s.checkIn(w, r); ok {
+		s.checkIns[p.ID] = append(s.checkIns[p.ID][:i], s.checkIns[p.ID][i+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// Uptime sites
+
+func (s *Server) listSites(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, ok := s.project(w, r); ok {
+		results(w, values(s.sites[p.ID]))
+	}
+}
+
+// site looks up the site named in the path. Callers must hold s.mu.
+func (s *Server) site(w http.ResponseWriter, r *http.Request) (*hbapi.Project, int, bool) {
+	p, ok := s.project(w, r)
+	if !ok {
+		return nil, 0, false
+	}
+	id := r.PathValue("siteID")
+	i := find(s.sites[p.ID], func(site *hbapi.Site) bool { return site.ID == id })
+	if i < 0 {
+		notFound(w)
+		return nil, 0, false
+	}
+	return p, i, true
+}
+
+func (s *Server) getSite(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, i, ok := s.site(w, r); ok {
+		writeJSON(w, http.StatusOK, s.sites[p.ID][i])
+	}
+}
+
+func applySiteParams(site *hbapi.Site, params hbapi.SiteParams) {
+	if params.Name != "" {
+		site.Name = params.Name
+	}
+	if params.URL != "" {
+		site.URL = params.URL
+	}
+	if params.Frequency != nil {
+		site.Frequency = *params.Frequency
+	}
+	if params.Match != nil {
+		site.Match = params.Match
+	}
+	if params.MatchType != nil {
+		site.MatchType = *params.MatchType
+	}
+	if params.Active != nil {
+		site.Active = *params.Active
+	}
+}
+
+func (s *Server) createSite(w http.ResponseWriter, r *http.Request) {
+	var body hbapi.SiteCreateRequest
+	if !decode(w, r, &body) {
+		return
+	}
+
+	errs := map[string][]string{}
+	if body.Site.Name == "" {
+		errs["name"] = []string{"can't be blank"}
+	}
+	if body.Site.URL == "" {
+		errs["url"] = []string{"can't be blank"}
+	}
+	if len(errs) > 0 {
+		writeValidationError(w, errs)
+		return
+	}
+
+	s.mu.Lock()
+	p, ok := s.project(w, r)
+	s.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	site := hbapi.Site{Active: true, Frequency: 5, MatchType: "success"}
+	applySiteParams(&site, body.Site)
+	writeJSON(w, http.StatusCreated, s.AddSite(p.ID, site))
+}
+
+func (s *Server) updateSite(w http.ResponseWriter, r *http.Request) {
+	var body hbapi.SiteCreateRequest
+	if !decode(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, i, ok := s.site(w, r); ok {
+		applySiteParams(s.sites[p.ID][i], body.Site)
+		writeJSON(w, http.StatusOK, s.sites[p.ID][i])
+	}
+}
+
+func (s *Server) deleteSite(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, i, ok := s.site(w, r); ok {
+		s.sites[p.ID] = append(s.sites[p.ID][:i], s.sites[p.ID][i+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) emptySiteResults(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, _, ok := s.site(w, r); ok {
+		results(w, []struct{}{})
+	}
+}
+
+// Environments
+
+func (s *Server) listEnvironments(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, ok := s.project(w, r); ok {
+		paginate(s, w, r, values(s.environments[p.ID]))
+	}
+}
+
+// environment looks up the environment named in the path. Callers must hold s.mu.
+func (s *Server) environment(w http.ResponseWriter, r *http.Request) (*hbapi.Project, int, bool) {
+	p, ok := s.project(w, r)
+	if !ok {
+		return nil, 0, false
+	}
+	id, ok := intParam(w, r, "environmentID")
+	if !ok {
+		return nil, 0, false
+	}
+	i := find(s.environments[p.ID], func(e *hbapi.Environment) bool { return e.ID == id })
+	if i < 0 {
+		notFound(w)
+		return nil, 0, false
+	}
+	return p, i, true
+}
+
+func (s *Server) getEnvironment(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, i, ok := s.environment(w, r); ok {
+		writeJSON(w, http.StatusOK, s.environments[p.ID][i])
+	}
+}
+
+func (s *Server) createEnvironment(w http.ResponseWriter, r *http.Request) {
+	var body hbapi.EnvironmentRequest
+	if !decode(w, r, &body) {
+		return
+	}
+	if body.Environment.Name == "" {
+		writeValidationError(w, map[string][]string{"name": {"can't be blank"}})
+		return
+	}
+
+	s.mu.Lock()
+	p, ok := s.project(w, r)
+	s.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	env := hbapi.Environment{Name: body.Environment.Name, Notifications: true}
+	if body.Environment.Notifications != nil {
+		env.Notifications = *body.Environment.Notifications
+	}
+	writeJSON(w, http.StatusCreated, s.AddEnvironment(p.ID, env))
+}
+
+func (s *Server) updateEnvironment(w http.ResponseWriter, r *http.Request) {
+	var body hbapi.EnvironmentRequest
+	if !decode(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, i, ok := s.environment(w, r); ok {
+		env := s.environments[p.ID][i]
+		if body.Environment.Name != "" {
+			env.Name = body.Environment.Name
+		}
+		if body.Environment.Notifications != nil {
+			env.Notifications = *body.Environment.Notifications
+		}
+		env.UpdatedAt = now()
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) deleteEnvironment(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, i, ok := s.environment(w, r); ok {
+		s.environments[p.ID] = append(s.environments[p.ID][:i], s.environments[p.ID][i+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// Dashboards
+
+type dashboardBody struct {
+	Dashboard hbapi.DashboardRequest `json:"dashboard"`
+}
+
+func (s *Server) listDashboards(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, ok := s.project(w, r); ok {
+		paginate(s, w, r, values(s.dashboards[p.ID]))
+	}
+}
+
+// dashboard looks up the dashboard named in the path. Callers must hold s.mu.
+func (s *Server) dashboard(w http.ResponseWriter, r *http.Request) (*hbapi.Project, int, bool) {
+	p, ok := s.project(w, r)
+	if !ok {
+		return nil, 0, false
+	}
+	id := r.PathValue("dashboardID")
+	i := find(s.dashboards[p.ID], func(d *hbapi.Dashboard) bool { return d.ID == id })
+	if i < 0 {
+		notFound(w)
+		return nil, 0, false
+	}
+	return p, i, true
+}
+
+func (s *Server) getDashboard(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, i, ok := s.dashboard(w, r); ok {
+		writeJSON(w, http.StatusOK, s.dashboards[p.ID][i])
+	}
+}
+
+func (s *Server) createDashboard(w http.ResponseWriter, r *http.Request) {
+	var body dashboardBody
+	if !decode(w, r, &body) {
+		return
+	}
+	if body.Dashboard.Title == "" {
+		writeValidationError(w, map[string][]string{"title": {"can't be blank"}})
+		return
+	}
+
+	s.mu.Lock()
+	p, ok := s.project(w, r)
+	s.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, s.AddDashboard(p.ID, hbapi.Dashboard{
+		Title:   body.Dashboard.Title,
+		Widgets: body.Dashboard.Widgets,
+	}))
+}
+
+func (s *Server) updateDashboard(w http.ResponseWriter, r *http.Request) {
+	var body dashboardBody
+	if !decode(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, i, ok := s.dashboard(w, r); ok {
+		d := s.dashboards[p.ID][i]
+		if body.Dashboard.Title != "" {
+			d.Title = body.Dashboard.Title
+		}
+		if body.Dashboard.Widgets != nil {
+			d.Widgets = body.Dashboard.Widgets
+		}
+		d.UpdatedAt = now()
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) deleteDashboard(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, i, ok := s.dashboard(w, r); ok {
+		s.dashboards[p.ID] = append(s.dashboards[p.ID][:i], s.dashboards[p.ID][i+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// Teams
+
+func (s *Server) listTeams(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	accountID := r.URL.Query().Get("account_id")
+	ids := make([]int, 0, len(s.teams))
+	for id := range s.teams {
+		if s.teamAccounts[id] == accountID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+
+	teams := make([]hbapi.Team, 0, len(ids))
+	for _, id := range ids {
+		teams = append(teams, *s.teams[id])
+	}
+	results(w, teams)
+}
+
+// team looks up the team named in the path. Callers must hold s.mu.
+func (s *Server) team(w http.ResponseWriter, r *http.Request) (*hbapi.Team, bool) {
+	id, ok := intParam(w, r, "teamID")
+	if !ok {
+		return nil, false
+	}
+	t, ok := s.teams[id]
+	if !ok {
+		notFound(w)
+		return nil, false
+	}
+	return t, true
+}
+
+func (s *Server) getTeam(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if t, ok := s.team(w, r); ok {
+		writeJSON(w, http.StatusOK, t)
+	}
+}
+
+func (s *Server) createTeam(w http.ResponseWriter, r *http.Request) {
+	var body hbapi.TeamRequest
+	if !decode(w, r, &body) {
+		return
+	}
+	if body.Team.Name == "" {
+		writeValidationError(w, map[string][]string{"name": {"can't be blank"}})
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, s.AddTeam(r.URL.Query().Get("account_id"), hbapi.Team{Name: body.Team.Name}))
+}
+
+func (s *Server) updateTeam(w http.ResponseWriter, r *http.Request) {
+	var body hbapi.TeamRequest
+	if !decode(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if t, ok := s.team(w, r); ok {
+		if body.Team.Name != "" {
+			t.Name = body.Team.Name
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) deleteTeam(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if t, ok := s.team(w, r); ok {
+		delete(s.teams, t.ID)
+		delete(s.teamAccounts, t.ID)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// Status pages
+
+func (s *Server) listStatusPages(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	results(w, values(s.statusPages[r.PathValue("accountID")]))
+}
+
+// statusPage looks up the status page named in the path. Callers must hold s.mu.
+func (s *Server) statusPage(w http.ResponseWriter, r *http.Request) (string, int, bool) {
+	accountID := r.PathValue("accountID")
+	id := r.PathValue("statusPageID")
+	i := find(s.statusPages[accountID], func(p *hbapi.StatusPage) bool { return p.ID == id })
+	if i < 0 {
+		notFound(w)
+		return "", 0, false
+	}
+	return accountID, i, true
+}
+
+func (s *Server) getStatusPage(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if accountID, i, ok := s.statusPage(w, r); ok {
+		writeJSON(w, http.StatusOK, s.statusPages[accountID][i])
+	}
+}
+
+func (s *Server) createStatusPage(w http.ResponseWriter, r *http.Request) {
+	var body hbapi.StatusPageRequest
+	if !decode(w, r, &body) {
+		return
+	}
+	if body.StatusPage.Name == "" {
+		writeValidationError(w, map[string][]string{"name": {"can't be blank"}})
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, s.AddStatusPage(r.PathValue("accountID"), hbapi.StatusPage{
+		Name:   body.StatusPage.Name,
+		Domain: body.StatusPage.Domain,
+	}))
+}
+
+func (s *Server) updateStatusPage(w http.ResponseWriter, r *http.Request) {
+	var body hbapi.StatusPageRequest
+	if !decode(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if accountID, i, ok := s.statusPage(w, r); ok {
+		page := s.statusPages[accountID][i]
+		if body.StatusPage.Name != "" {
+			page.Name = body.StatusPage.Name
+		}
+		if body.StatusPage.Domain != nil {
+			page.Domain = body.StatusPage.Domain
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) deleteStatusPage(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if accountID, i, ok := s.statusPage(w, r); ok {
+		s.statusPages[accountID] = append(s.statusPages[accountID][:i], s.statusPages[accountID][i+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,546 @@
+package hbapitest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	hbapi "github.com/honeybadger-io/api-go"
+)
+
+func TestProjects_CRUD(t *testing.T) {
+	fake := NewTestServer(t)
+	fake.AddProject("acct-2", hbapi.Project{Name: "Other"})
+	client := newClient(fake)
+	ctx := context.Background()
+
+	project, err := client.Projects.Create(ctx, "acct-1", hbapi.ProjectRequest{Name: "Shop"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if project.Name != "Shop" {
+		t.Errorf("expected name Shop, got %s", project.Name)
+	}
+
+	if _, err := client.Projects.Update(ctx, project.ID, hbapi.ProjectRequest{Name: "Storefront"}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	got, err := client.Projects.Get(ctx, project.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Name != "Storefront" {
+		t.Errorf("expected name Storefront, got %s", got.Name)
+	}
+
+	response, err := client.Projects.ListByAccountID(ctx, "acct-1")
+	if err != nil {
+		t.Fatalf("ListByAccountID() error = %v", err)
+	}
+	if len(response.Results) != 1 || response.Results[0].ID != project.ID {
+		t.Errorf("expected only project %d, got %+v", project.ID, response.Results)
+	}
+
+	if _, err := client.Projects.Delete(ctx, project.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := client.Projects.Get(ctx, project.ID); !errors.Is(err, hbapi.ErrNotFound) {
+		t.Errorf("expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestProjects_CreateValidation(t *testing.T) {
+	fake := NewTestServer(t)
+
+	_, err := newClient(fake).Projects.Create(context.Background(), "acct-1", hbapi.ProjectRequest{})
+	if !errors.Is(err, hbapi.ErrValidation) {
+		t.Fatalf("expected ErrValidation, got %v", err)
+	}
+
+	var validation hbapi.ValidationError
+	if !errors.As(err, &validation) {
+		t.Fatalf("expected ValidationError, got %T", err)
+	}
+	if len(validation["name"]) != 1 || validation["name"][0] != "can't be blank" {
+		t.Errorf("expected name can't be blank, got %v", validation)
+	}
+}
+
+func TestFaults_Search(t *testing.T) {
+	fake := NewTestServer(t)
+	project := fake.AddProject("acct-1", hbapi.Project{Name: "Shop"})
+	fake.AddFault(project.ID, hbapi.Fault{Klass: "RuntimeError", Environment: "production"})
+	fake.AddFault(project.ID, hbapi.Fault{Klass: "NoMethodError", Environment: "production", Resolved: true})
+	fake.AddFault(project.ID, hbapi.Fault{Klass: "RuntimeError", Environment: "staging", Tags: []string{"billing"}})
+
+	client := newClient(fake)
+	tests := []struct {
+		q        string
+		expected int
+	}{
+		{"", 3},
+		{"-is:resolved", 2},
+		{"is:resolved", 1},
+		{"environment:production", 2},
+		{"class:RuntimeError -environment:staging", 1},
+		{"tag:billing", 1},
+		{"nomethod", 1},
+	}
+	for _, tt := range tests {
+		response, err := client.Faults.List(context.Background(), project.ID, hbapi.FaultListOptions{Q: tt.q})
+		if err != nil {
+			t.Fatalf("List(%q) error = %v", tt.q, err)
+		}
+		if len(response.Results) != tt.expected {
+			t.Errorf("List(%q): expected %d faults, got %d", tt.q, tt.expected, len(response.Results))
+		}
+	}
+
+	counts, err := client.Faults.GetCounts(context.Background(), project.ID, hbapi.FaultListOptions{Q: "-is:resolved"})
+	if err != nil {
+		t.Fatalf("GetCounts() error = %v", err)
+	}
+	if counts.Total != 2 || len(counts.Environments) != 2 {
+		t.Errorf("expected 2 faults in 2 environments, got %+v", counts)
+	}
+}
+
+func TestFaults_NoticesAndAffectedUsers(t *testing.T) {
+	fake := NewTestServer(t)
+	project := fake.AddProject("acct-1", hbapi.Project{Name: "Shop"})
+	fault := fake.AddFault(project.ID, hbapi.Fault{Klass: "RuntimeError"})
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, email := range []string{"a@example.com", "b@example.com", "a@example.com"} {
+		fake.AddNotice(project.ID, fault.ID, hbapi.Notice{
+			CreatedAt: base.Add(time.Duration(i) * time.Hour),
+			Request:   hbapi.NoticeRequest{User: map[string]interface{}{"email": email}},
+		})
+	}
+
+	client := newClient(fake)
+	got, err := client.Faults.Get(context.Background(), project.ID, fault.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.NoticesCount != 3 {
+		t.Errorf("expected 3 notices, got %d", got.NoticesCount)
+	}
+
+	notices, err := client.Faults.ListNotices(context.Background(), project.ID, fault.ID, hbapi.FaultListNoticesOptions{})
+	if err != nil {
+		t.Fatalf("ListNotices() error = %v", err)
+	}
+	if len(notices.Results) != 3 || !notices.Results[0].CreatedAt.Equal(base.Add(2*time.Hour)) {
+		t.Errorf("expected 3 notices, most recent first, got %+v", notices.Results)
+	}
+
+	users, err := client.Faults.ListAffectedUsers(context.Background(), project.ID, fault.ID, hbapi.FaultListAffectedUsersOptions{})
+	if err != nil {
+		t.Fatalf("ListAffectedUsers() error = %v", err)
+	}
+	if len(users) != 2 || users[0].User != "a@example.com" || users[0].Count != 2 {
+		t.Errorf("unexpected affected users %+v", users)
+	}
+}
+
+func TestComments_CRUD(t *testing.T) {
+	fake := NewTestServer(t)
+	project := fake.AddProject("acct-1", hbapi.Project{Name: "Shop"})
+	fault := fake.AddFault(project.ID, hbapi.Fault{Klass: "RuntimeError"})
+	client := newClient(fake)
+	ctx := context.Background()
+
+	comment, err := client.Comments.Create(ctx, project.ID, fault.ID, "First")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := client.Comments.Update(ctx, project.ID, fault.ID, comment.ID, "Edited"); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	comments, err := client.Comments.List(ctx, project.ID, fault.ID)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(comments) != 1 || comments[0].Body != "Edited" {
+		t.Errorf("expected one edited comment, got %+v", comments)
+	}
+	if f, _ := fake.Fault(project.ID, fault.ID); f.CommentsCount != 1 {
+		t.Errorf("expected comments count 1, got %d", f.CommentsCount)
+	}
+
+	if err := client.Comments.Delete(ctx, project.ID, fault.ID, comment.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := client.Comments.Get(ctx, project.ID, fault.ID, comment.ID); !errors.Is(err, hbapi.ErrNotFound) {
+		t.Errorf("expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestCheckIns_CRUD(t *testing.T) {
+	fake := NewTestServer(t)
+	project := fake.AddProject("acct-1", hbapi.Project{Name: "Shop"})
+	client := newClient(fake)
+	ctx := context.Background()
+
+	period := "1 hour"
+	checkIn, err := client.CheckIns.Create(ctx, project.ID, hbapi.CheckInParams{Name: "Nightly", ReportPeriod: &period})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if checkIn.ScheduleType != "simple" || checkIn.State != "pending" {
+		t.Errorf("expected pending simple check-in, got %+v", checkIn)
+	}
+
+	if err := client.CheckIns.Update(ctx, project.ID, checkIn.ID, hbapi.CheckInParams{Name: "Hourly"}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	got, err := client.CheckIns.Get(ctx, project.ID, checkIn.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Name != "Hourly" {
+		t.Errorf("expected name Hourly, got %s", got.Name)
+	}
+
+	if err := client.CheckIns.Delete(ctx, project.ID, checkIn.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	checkIns, err := client.CheckIns.List(ctx, project.ID)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(checkIns) != 0 {
+		t.Errorf("expected no check-ins, got %d", len(checkIns))
+	}
+
+	_, err = client.CheckIns.Create(ctx, project.ID, hbapi.CheckInParams{ScheduleType: "cron"})
+	var validation hbapi.ValidationError
+	if !errors.As(err, &validation) || len(validation) != 2 {
+		t.Errorf("expected validation errors for name and cron_schedule, got %v", err)
+	}
+}
+
+func TestUptime_CRUD(t *testing.T) {
+	fake := NewTestServer(t)
+	project := fake.AddProject("acct-1", hbapi.Project{Name: "Shop"})
+	client := newClient(fake)
+	ctx := context.Background()
+
+	site, err := client.Uptime.Create(ctx, project.ID, hbapi.SiteParams{Name: "Home", URL: "https://example.com"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	frequency := 1
+	updated, err := client.Uptime.Update(ctx, project.ID, site.ID, hbapi.SiteParams{Frequency: &frequency})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if updated.Frequency != 1 || updated.URL != "https://example.com" {
+		t.Errorf("unexpected updated site %+v", updated)
+	}
+
+	outages, err := client.Uptime.ListOutages(ctx, project.ID, site.ID, hbapi.OutageListOptions{})
+	if err != nil {
+		t.Fatalf("ListOutages() error = %v", err)
+	}
+	if len(outages) != 0 {
+		t.Errorf("expected no outages, got %d", len(outages))
+	}
+
+	if err := client.Uptime.Delete(ctx, project.ID, site.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := client.Uptime.Get(ctx, project.ID, site.ID); !errors.Is(err, hbapi.ErrNotFound) {
+		t.Errorf("expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestEnvironments_CRUD(t *testing.T) {
+	fake := NewTestServer(t)
+	project := fake.AddProject("acct-1", hbapi.Project{Name: "Shop"})
+	client := newClient(fake)
+	ctx := context.Background()
+
+	env, err := client.Environments.Create(ctx, project.ID, hbapi.EnvironmentParams{Name: "production"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if !env.Notifications {
+		t.Error("expected notifications to default to true")
+	}
+
+	notifications := false
+	if err := client.Environments.Update(ctx, project.ID, env.ID, hbapi.EnvironmentParams{Notifications: &notifications}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	got, err := client.Environments.Get(ctx, project.ID, env.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Notifications || got.Name != "production" {
+		t.Errorf("unexpected environment %+v", got)
+	}
+
+	if err := client.Environments.Delete(ctx, project.ID, env.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	envs, err := client.Environments.List(ctx, project.ID)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(envs) != 0 {
+		t.Errorf("expected no environments, got %d", len(envs))
+	}
+}
+
+func TestTeams_CRUD(t *testing.T) {
+	fake := NewTestServer(t)
+	fake.AddTeam("acct-2", hbapi.Team{Name: "Other"})
+	client := newClient(fake)
+	ctx := context.Background()
+
+	team, err := client.Teams.Create(ctx, "acct-1", "Backend")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := client.Teams.Update(ctx, team.ID, "Platform"); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	teams, err := client.Teams.List(ctx, "acct-1")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(teams) != 1 || teams[0].Name != "Platform" {
+		t.Errorf("expected one team named Platform, got %+v", teams)
+	}
+
+	if err := client.Teams.Delete(ctx, team.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := client.Teams.Get(ctx, team.ID); !errors.Is(err, hbapi.ErrNotFound) {
+		t.Errorf("expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestDashboards_CRUD(t *testing.T) {
+	fake := NewTestServer(t).SetPageSize(1)
+	project := fake.AddProject("acct-1", hbapi.Project{Name: "Shop"})
+	fake.AddDashboard(project.ID, hbapi.Dashboard{Title: "Seeded"})
+	client := newClient(fake)
+	ctx := context.Background()
+
+	dashboard, err := client.Dashboards.Create(ctx, project.ID, hbapi.DashboardRequest{Title: "Errors"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if _, err := client.Dashboards.Update(ctx, project.ID, dashboard.ID, hbapi.DashboardRequest{Title: "All errors"}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	var titles []string
+	for d, err := range client.Dashboards.All(ctx, project.ID) {
+		if err != nil {
+			t.Fatalf("All() error = %v", err)
+		}
+		titles = append(titles, d.Title)
+	}
+	if len(titles) != 2 || titles[1] != "All errors" {
+		t.Errorf("expected [Seeded All errors], got %v", titles)
+	}
+
+	if _, err := client.Dashboards.Delete(ctx, project.ID, dashboard.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := client.Dashboards.Get(ctx, project.ID, dashboard.ID); !errors.Is(err, hbapi.ErrNotFound) {
+		t.Errorf("expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestStatusPages_CRUD(t *testing.T) {
+	fake := NewTestServer(t)
+	client := newClient(fake)
+	ctx := context.Background()
+
+	page, err := client.StatusPages.Create(ctx, "acct-1", hbapi.StatusPageParams{Name: "Status"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if page.AccountID != "acct-1" {
+		t.Errorf("expected account acct-1, got %s", page.AccountID)
+	}
+
+	if err := client.StatusPages.Update(ctx, "acct-1", page.ID, hbapi.StatusPageParams{Name: "System status"}); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	pages, err := client.StatusPages.List(ctx, "acct-1")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(pages) != 1 || pages[0].Name != "System status" {
+		t.Errorf("expected one page named System status, got %+v", pages)
+	}
+
+	if err := client.StatusPages.Delete(ctx, "acct-1", page.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := client.StatusPages.Get(ctx, "acct-1", page.ID); !errors.Is(err, hbapi.ErrNotFound) {
+		t.Errorf("expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestFaults_Update(t *testing.T) {
+	fake := NewTestServer(t)
+	project := fake.AddProject("acct-1", hbapi.Project{Name: "Shop"})
+	fault := fake.AddFault(project.ID, hbapi.Fault{Klass: "RuntimeError"})
+	client := newClient(fake)
+	ctx := context.Background()
+
+	if err := client.Faults.Resolve(ctx, project.ID, fault.ID); err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if err := client.Faults.Assign(ctx, project.ID, fault.ID, 7); err != nil {
+		t.Fatalf("Assign() error = %v", err)
+	}
+
+	got, _ := fake.Fault(project.ID, fault.ID)
+	if !got.Resolved || got.Assignee == nil || got.Assignee.ID != 7 {
+		t.Errorf("expected resolved fault assigned to 7, got %+v", got)
+	}
+	if p, _ := fake.Project(project.ID); p.UnresolvedFaultCount != 0 {
+		t.Errorf("expected no unresolved faults, got %d", p.UnresolvedFaultCount)
+	}
+
+	if err := client.Faults.Unassign(ctx, project.ID, fault.ID); err != nil {
+		t.Fatalf("Unassign() error = %v", err)
+	}
+	if got, _ := fake.Fault(project.ID, fault.ID); got.Assignee != nil || !got.Resolved {
+		t.Errorf("expected resolved fault without assignee, got %+v", got)
+	}
+}
+
+func TestFaults_MergeDeletePause(t *testing.T) {
+	fake := NewTestServer(t)
+	project := fake.AddProject("acct-1", hbapi.Project{Name: "Shop"})
+	target := fake.AddFault(project.ID, hbapi.Fault{Klass: "RuntimeError"})
+	duplicate := fake.AddFault(project.ID, hbapi.Fault{Klass: "RuntimeError"})
+	junk := fake.AddFault(project.ID, hbapi.Fault{Klass: "Junk"})
+	fake.AddNotice(project.ID, target.ID, hbapi.Notice{})
+	fake.AddNotice(project.ID, duplicate.ID, hbapi.Notice{})
+	client := newClient(fake)
+	ctx := context.Background()
+
+	if err := client.Faults.Merge(ctx, project.ID, target.ID, duplicate.ID); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if got, _ := fake.Fault(project.ID, target.ID); got.NoticesCount != 2 {
+		t.Errorf("expected 2 notices after merge, got %d", got.NoticesCount)
+	}
+	if _, ok := fake.Fault(project.ID, duplicate.ID); ok {
+		t.Error("expected duplicate to be removed by merge")
+	}
+
+	if err := client.Faults.Delete(ctx, project.ID, junk.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if p, _ := fake.Project(project.ID); p.FaultCount != 1 {
+		t.Errorf("expected 1 fault left, got %d", p.FaultCount)
+	}
+
+	if err := client.Faults.PauseNotifications(ctx, project.ID, target.ID, hbapi.PauseFor(hbapi.PauseHour)); err != nil {
+		t.Fatalf("PauseNotifications() error = %v", err)
+	}
+	if !fake.Paused(target.ID) {
+		t.Error("expected fault to be paused")
+	}
+	if err := client.Faults.UnpauseNotifications(ctx, project.ID, target.ID); err != nil {
+		t.Fatalf("UnpauseNotifications() error = %v", err)
+	}
+	if fake.Paused(target.ID) {
+		t.Error("expected fault to be unpaused")
+	}
+
+	// The client rejects other counts, so send one directly
+	req, _ := http.NewRequest("POST", fmt.Sprintf("%s/v2/projects/%d/faults/%d/pause", fake.URL, project.ID, target.ID), strings.NewReader(`{"count":50}`))
+	req.SetBasicAuth("test-token", "")
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("pause request error = %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("expected status 422 for an unsupported count, got %d", resp.StatusCode)
+	}
+	if fake.Paused(target.ID) {
+		t.Error("expected fault to stay unpaused")
+	}
+}
+
+func TestFaults_UpdateNormalizesTags(t *testing.T) {
+	fake := NewTestServer(t)
+	project := fake.AddProject("acct-1", hbapi.Project{Name: "Shop"})
+	fault := fake.AddFault(project.ID, hbapi.Fault{Klass: "RuntimeError"})
+	client := newClient(fake)
+	ctx := context.Background()
+
+	tests := []struct {
+		tags     []string
+		expected string
+	}{
+		{[]string{"billing", "api"}, "[api billing]"},
+		{[]string{"a,b", " c\td ", "a"}, "[a b c d]"},
+		{[]string{"Billing, billing", "BILLING"}, "[billing]"},
+		{[]string{"routing:SRE", "p-1,v1.2;x", "team_a."}, "[p-1 routing:sre team_a. v1.2;x]"},
+		{[]string{"", " , "}, "[]"},
+	}
+	for _, tt := range tests {
+		// Send the tags as given, so the fake's rules are checked on their own
+		if err := client.Faults.Update(ctx, project.ID, fault.ID, hbapi.FaultUpdateParams{Tags: tt.tags}); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		got, _ := fake.Fault(project.ID, fault.ID)
+		if fmt.Sprint(got.Tags) != tt.expected {
+			t.Errorf("tags %q: expected stored tags %s, got %v", tt.tags, tt.expected, got.Tags)
+		}
+		if normalized := hbapi.NormalizeTags(tt.tags...); fmt.Sprint(normalized) != tt.expected {
+			t.Errorf("tags %q: expected NormalizeTags to return %s, got %v", tt.tags, tt.expected, normalized)
+		}
+	}
+}
+
+func TestFaults_BulkTag(t *testing.T) {
+	fake := NewTestServer(t)
+	project := fake.AddProject("acct-1", hbapi.Project{Name: "Shop"})
+	timeout := fake.AddFault(project.ID, hbapi.Fault{Klass: "Net::ReadTimeout", Tags: []string{"db"}})
+	other := fake.AddFault(project.ID, hbapi.Fault{Klass: "RuntimeError"})
+	client := newClient(fake)
+	ctx := context.Background()
+
+	report, err := client.Faults.BulkTag(ctx, project.ID, hbapi.FaultQuery().Class("Net::ReadTimeout"), []string{"routing:sre"}, hbapi.BulkUpdateOptions{})
+	if err != nil {
+		t.Fatalf("BulkTag() error = %v", err)
+	}
+	if len(report.Succeeded()) != 1 {
+		t.Fatalf("expected 1 fault to be tagged, got %d", len(report.Succeeded()))
+	}
+	if got, _ := fake.Fault(project.ID, timeout.ID); fmt.Sprint(got.Tags) != "[db routing:sre]" {
+		t.Errorf("expected tags [db routing:sre], got %v", got.Tags)
+	}
+	if got, _ := fake.Fault(project.ID, other.ID); len(got.Tags) != 0 {
+		t.Errorf("expected other fault to be untouched, got %v", got.Tags)
+	}
+
+	if _, err := client.Faults.RemoveTags(ctx, project.ID, timeout.ID, "db"); err != nil {
+		t.Fatalf("RemoveTags() error = %v", err)
+	}
+	if got, _ := fake.Fault(project.ID, timeout.ID); fmt.Sprint(got.Tags) != "[routing:sre]" {
+		t.Errorf("expected tags [routing:sre], got %v", got.Tags)
+	}
+}
@@ -0,0 +1,86 @@
+package hbapitest
+
+import (
+	"strings"
+
+	hbapi "github.com/honeybadger-io/api-go"
+)
+
+// searchTerm is a single term of a fault search query, e.g. -is:resolved or environment:production
+type searchTerm struct {
+	negate bool
+	key    string // Empty for free-text terms
+	value  string
+}
+
+// search is a parsed fault search query. It supports the subset of the
+// Honeybadger search syntax that is useful in tests: is:resolved, is:ignored,
+// is:assigned, environment:, class:, tag:, assignee: and free text matched
+// against the class and message.
+type search []searchTerm
+
+var supportedKeys = map[string]bool{
+	"": true, "is": true, "environment": true, "class": true, "tag": true, "assignee": true,
+}
+
+// parseSearch parses a query with hbapi.ParseFaultQuery. Queries that do not
+// parse, e.g. with an unterminated quote, match nothing.
+func parseSearch(q string) search {
+	parsed, err := hbapi.ParseFaultQuery(q)
+	if err != nil {
+		return search{{key: "is", value: "unparseable"}}
+	}
+
+	var terms search
+	for _, t := range parsed.Terms() {
+		if !supportedKeys[t.Key] {
+			// Unsupported filters are ignored so tests are not tripped up by them
+			continue
+		}
+		terms = append(terms, searchTerm{negate: t.Negate, key: t.Key, value: t.Value})
+	}
+	return terms
+}
+
+func (s search) matches(f *hbapi.Fault) bool {
+	for _, term := range s {
+		if term.matches(f) == term.negate {
+			return false
+		}
+	}
+	return true
+}
+
+func (t searchTerm) matches(f *hbapi.Fault) bool {
+	switch t.key {
+	case "is":
+		switch strings.ToLower(t.value) {
+		case "resolved":
+			return f.Resolved
+		case "ignored":
+			return f.Ignored
+		case "assigned":
+			return f.Assignee != nil
+		}
+		return false
+	case "environment":
+		return f.Environment == t.value
+	case "class":
+		return f.Klass == t.value
+	case "tag":
+		for _, tag := range f.Tags {
+			if strings.EqualFold(tag, t.value) {
+				return true
+			}
+		}
+		return false
+	case "assignee":
+		if t.value == "nobody" {
+			return f.Assignee == nil
+		}
+		return f.Assignee != nil && (f.Assignee.Email == t.value || f.Assignee.Name == t.value)
+	default:
+		text := strings.ToLower(t.value)
+		return strings.Contains(strings.ToLower(f.Klass), text) || strings.Contains(strings.ToLower(f.Message), text)
+	}
+}
@@ -0,0 +1,63 @@
+package hbapitest
+
+import (
+	"testing"
+
+	hbapi "github.com/honeybadger-io/api-go"
+)
+
+func TestParseSearch(t *testing.T) {
+	terms := parseSearch(`-is:resolved class:"Net::ReadTimeout" unknown:value timeout`)
+
+	expected := search{
+		{negate: true, key: "is", value: "resolved"},
+		{key: "class", value: "Net::ReadTimeout"},
+		{value: "timeout"},
+	}
+	if len(terms) != len(expected) {
+		t.Fatalf("expected %d terms, got %d: %+v", len(expected), len(terms), terms)
+	}
+	for i := range expected {
+		if terms[i] != expected[i] {
+			t.Errorf("term %d: expected %+v, got %+v", i, expected[i], terms[i])
+		}
+	}
+}
+
+func TestSearch_Matches(t *testing.T) {
+	fault := &hbapi.Fault{
+		Klass:       "Net::ReadTimeout",
+		Message:     "execution expired",
+		Environment: "production",
+		Tags:        []string{"network"},
+		Assignee:    &hbapi.User{Email: "dev@example.com"},
+	}
+
+	tests := []struct {
+		q        string
+		expected bool
+	}{
+		{"", true},
+		{"is:resolved", false},
+		{"-is:resolved", true},
+		{"is:assigned assignee:dev@example.com", true},
+		{"assignee:nobody", false},
+		{`class:"Net::ReadTimeout"`, true},
+		{"environment:staging", false},
+		{"tag:network EXPIRED", true},
+		{"-tag:network", false},
+	}
+	for _, tt := range tests {
+		if got := parseSearch(tt.q).matches(fault); got != tt.expected {
+			t.Errorf("%q: expected %v, got %v", tt.q, tt.expected, got)
+		}
+	}
+}
+
+func TestParseSearch_EscapedQuotes(t *testing.T) {
+	q := hbapi.FaultQuery().Text(`say "hi"`).Tag("billing").String()
+	terms := parseSearch(q)
+	if len(terms) != 2 || terms[0].value != `say "hi"` {
+		t.Errorf("expected escaped quotes to round-trip, got %+v", terms)
+	}
+}
@@ -0,0 +1,310 @@
+// Package hbapitest provides an in-memory fake of the Honeybadger Data API for tests.
+//
+// The fake keeps state between requests, so resources created through the client
+// can be read back, updated and deleted. Seed it with the Add methods, point a
+// client at it with WithBaseURL, and inject failures with InjectError:
+//
+//	fake := hbapitest.NewServer()
+//	defer fake.Close()
+//
+//	project := fake.AddProject("acct-1", hbapi.Project{Name: "Shop"})
+//	fake.AddFault(project.ID, hbapi.Fault{Klass: "RuntimeError"})
+//
+//	client := hbapi.NewClient().WithBaseURL(fake.URL).WithAuthToken("test-token")
+package hbapitest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	hbapi "github.com/honeybadger-io/api-go"
+)
+
+// DefaultPageSize is the number of items returned per page by paginated endpoints
+const DefaultPageSize = 25
+
+// Server is a stateful fake of the Honeybadger Data API backed by httptest.Server.
+// It is safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	nextID   int
+	token    string
+	pageSize int
+	errors   []*InjectedError
+	requests []Request
+
+	projects     map[int]*hbapi.Project
+	accountOf    map[int]string           // Account ID for each project
+	faults       map[int][]*hbapi.Fault   // Keyed by project ID
+	notices      map[int][]hbapi.Notice   // Keyed by fault ID
+	comments     map[int][]*hbapi.Comment // Keyed by fault ID
+	paused       map[int]bool             // Fault IDs with paused notifications
+	checkIns     map[int][]*hbapi.CheckIn
+	sites        map[int][]*hbapi.Site
+	environments map[int][]*hbapi.Environment
+	dashboards   map[int][]*hbapi.Dashboard
+	teams        map[int]*hbapi.Team
+	teamAccounts map[int]string // Account ID for each team
+	statusPages  map[string][]*hbapi.StatusPage
+}
+
+// Request records a request received by the fake
+type Request struct {
+	Method string
+	Path   string // Path without the /v2 prefix
+	Query  url.Values
+	Body   []byte
+}
+
+// InjectedError describes a failure the fake returns instead of handling a request
+type InjectedError struct {
+	Method     string      // HTTP method to match, or empty for any
+	Path       string      // Path without the /v2 prefix to match, or empty for any
+	StatusCode int         // Status code to respond with
+	Body       string      // Response body; defaults to {"errors": "<status text>"}
+	Header     http.Header // Extra response headers, e.g. Retry-After
+	Times      int         // Number of requests to fail; 0 fails every matching request
+}
+
+// NewServer starts a new fake server. Callers must call Close when done.
+func NewServer() *Server {
+	s := &Server{
+		nextID:       1,
+		pageSize:     DefaultPageSize,
+		projects:     map[int]*hbapi.Project{},
+		accountOf:    map[int]string{},
+		faults:       map[int][]*hbapi.Fault{},
+		notices:      map[int][]hbapi.Notice{},
+		comments:     map[int][]*hbapi.Comment{},
+		paused:       map[int]bool{},
+		checkIns:     map[int][]*hbapi.CheckIn{},
+		sites:        map[int][]*hbapi.Site{},
+		environments: map[int][]*hbapi.Environment{},
+		dashboards:   map[int][]*hbapi.Dashboard{},
+		teams:        map[int]*hbapi.Team{},
+		teamAccounts: map[int]string{},
+		statusPages:  map[string][]*hbapi.StatusPage{},
+	}
+	s.Server = httptest.NewServer(s.routes())
+	return s
+}
+
+// NewTestServer starts a new fake server that is closed when the test finishes
+func NewTestServer(tb testing.TB) *Server {
+	tb.Helper()
+	s := NewServer()
+	tb.Cleanup(s.Close)
+	return s
+}
+
+// RequireToken makes the fake reject requests whose Basic Auth username is not token.
+// By default any non-empty token is accepted.
+func (s *Server) RequireToken(token string) *Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = token
+	return s
+}
+
+// SetPageSize sets the number of items returned per page by paginated endpoints
+func (s *Server) SetPageSize(n int) *Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if n < 1 {
+		n = DefaultPageSize
+	}
+	s.pageSize = n
+	return s
+}
+
+// InjectError makes matching requests fail with the given status and body
+func (s *Server) InjectError(e InjectedError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errors = append(s.errors, &e)
+}
+
+// ClearErrors removes all injected errors
+func (s *Server) ClearErrors() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errors = nil
+}
+
+// Requests returns the requests received so far, in order
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// intercept records requests, checks authentication and applies injected errors before routing
+func (s *Server) intercept(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(strings.NewReader(string(body)))
+		path := strings.TrimPrefix(r.URL.Path, "/v2")
+
+		s.mu.Lock()
+		s.requests = append(s.requests, Request{
+			Method: r.Method,
+			Path:   path,
+			Query:  r.URL.Query(),
+			Body:   body,
+		})
+		injected := s.matchError(r.Method, path)
+		token := s.token
+		s.mu.Unlock()
+
+		if injected != nil {
+			for key, values := range injected.Header {
+				for _, v := range values {
+					w.Header().Add(key, v)
+				}
+			}
+			body := injected.Body
+			if body == "" {
+				body = fmt.Sprintf(`{"errors": %q}`, http.StatusText(injected.StatusCode))
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(injected.StatusCode)
+			_, _ = io.WriteString(w, body)
+			return
+		}
+
+		username, _, ok := r.BasicAuth()
+		if !ok || username == "" || (token != "" && username != token) {
+			writeError(w, http.StatusUnauthorized, "Authentication required")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// matchError returns the first injected error matching the request, consuming one use.
+// Callers must hold s.mu.
+func (s *Server) matchError(method, path string) *InjectedError {
+	for i, e := range s.errors {
+		if e.Method != "" && !strings.EqualFold(e.Method, method) {
+			continue
+		}
+		if e.Path != "" && e.Path != path {
+			continue
+		}
+		if e.Times > 0 {
+			e.Times--
+			if e.Times == 0 {
+				s.errors = append(s.errors[:i], s.errors[i+1:]...)
+			}
+		}
+		return e
+	}
+	return nil
+}
+
+// newID returns the next numeric ID. Callers must hold s.mu.
+func (s *Server) newID() int {
+	id := s.nextID
+	s.nextID++
+	return id
+}
+
+// now returns the current time truncated to seconds, as the API reports it
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"errors": message})
+}
+
+func writeValidationError(w http.ResponseWriter, fields map[string][]string) {
+	writeJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{"errors": fields})
+}
+
+func notFound(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, "Not found")
+}
+
+// decode reads a JSON request body into v, writing a 400 response on failure
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// intParam parses a numeric path parameter, writing a 404 response on failure
+func intParam(w http.ResponseWriter, r *http.Request, name string) (int, bool) {
+	v, err := strconv.Atoi(r.PathValue(name))
+	if err != nil {
+		notFound(w)
+		return 0, false
+	}
+	return v, true
+}
+
+// paginate writes one page of items as a ListResponse with realistic links.
+// Callers must hold s.mu.
+func paginate[T any](s *Server, w http.ResponseWriter, r *http.Request, items []T) {
+	pageSize := s.pageSize
+	if limit, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && limit > 0 && limit < pageSize {
+		pageSize = limit
+	}
+	page := 1
+	if p, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil && p > 0 {
+		page = p
+	}
+
+	start := (page - 1) * pageSize
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + pageSize
+	if end > len(items) {
+		end = len(items)
+	}
+
+	link := func(page int) string {
+		query := r.URL.Query()
+		query.Set("page", strconv.Itoa(page))
+		return fmt.Sprintf("%s%s?%s", s.URL, r.URL.Path, query.Encode())
+	}
+
+	response := hbapi.ListResponse[T]{
+		Results: append([]T{}, items[start:end]...),
+		Links:   hbapi.PaginationLinks{Self: link(page)},
+	}
+	if end < len(items) {
+		response.Links.Next = link(page + 1)
+	}
+	if page > 1 {
+		response.Links.Prev = link(page - 1)
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// results writes items wrapped in a {"results": [...]} envelope without pagination
+func results[T any](w http.ResponseWriter, items []T) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{"results": append([]T{}, items...)})
+}
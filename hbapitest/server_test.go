@@ -0,0 +1,142 @@
+package hbapitest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	hbapi "github.com/honeybadger-io/api-go"
+)
+
+func newClient(s *Server) *hbapi.Client {
+	return hbapi.NewClient().WithBaseURL(s.URL).WithAuthToken("test-token")
+}
+
+func TestServer_Authentication(t *testing.T) {
+	fake := NewTestServer(t).RequireToken("secret")
+	fake.AddProject("acct-1", hbapi.Project{Name: "Shop"})
+
+	_, err := newClient(fake).Projects.ListAll(context.Background())
+	if !errors.Is(err, hbapi.ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized, got %v", err)
+	}
+
+	client := hbapi.NewClient().WithBaseURL(fake.URL).WithAuthToken("secret")
+	response, err := client.Projects.ListAll(context.Background())
+	if err != nil {
+		t.Fatalf("ListAll() error = %v", err)
+	}
+	if len(response.Results) != 1 {
+		t.Errorf("expected 1 project, got %d", len(response.Results))
+	}
+}
+
+func TestServer_InjectError(t *testing.T) {
+	fake := NewTestServer(t)
+	project := fake.AddProject("acct-1", hbapi.Project{Name: "Shop"})
+
+	fake.InjectError(InjectedError{
+		Method:     "GET",
+		Path:       "/projects/" + strconv.Itoa(project.ID),
+		StatusCode: http.StatusServiceUnavailable,
+		Header:     http.Header{"Retry-After": {"0"}},
+		Times:      1,
+	})
+
+	client := newClient(fake)
+	_, err := client.Projects.Get(context.Background(), project.ID)
+	if !errors.Is(err, hbapi.ErrServer) {
+		t.Fatalf("expected ErrServer, got %v", err)
+	}
+
+	// The injected error was only good for one request
+	if _, err := client.Projects.Get(context.Background(), project.ID); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+}
+
+func TestServer_InjectError_Retried(t *testing.T) {
+	fake := NewTestServer(t)
+	project := fake.AddProject("acct-1", hbapi.Project{Name: "Shop"})
+	fake.InjectError(InjectedError{StatusCode: http.StatusTooManyRequests, Times: 2})
+
+	client := newClient(fake).WithRetryPolicy(hbapi.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond})
+	if _, err := client.Projects.Get(context.Background(), project.ID); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if got := len(fake.Requests()); got != 3 {
+		t.Errorf("expected 3 requests, got %d", got)
+	}
+}
+
+func TestServer_Requests(t *testing.T) {
+	fake := NewTestServer(t)
+	project := fake.AddProject("acct-1", hbapi.Project{Name: "Shop"})
+	fault := fake.AddFault(project.ID, hbapi.Fault{Klass: "RuntimeError"})
+
+	if _, err := newClient(fake).Comments.Create(context.Background(), project.ID, fault.ID, "Looking into it"); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	requests := fake.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(requests))
+	}
+	if requests[0].Method != "POST" {
+		t.Errorf("expected method POST, got %s", requests[0].Method)
+	}
+	expectedPath := "/projects/" + strconv.Itoa(project.ID) + "/faults/" + strconv.Itoa(fault.ID) + "/comments"
+	if requests[0].Path != expectedPath {
+		t.Errorf("expected path %s, got %s", expectedPath, requests[0].Path)
+	}
+	if string(requests[0].Body) != `{"comment":{"body":"Looking into it"}}` {
+		t.Errorf("unexpected body %s", requests[0].Body)
+	}
+}
+
+func TestServer_Pagination(t *testing.T) {
+	fake := NewTestServer(t).SetPageSize(2)
+	project := fake.AddProject("acct-1", hbapi.Project{Name: "Shop"})
+	for i := 0; i < 5; i++ {
+		fake.AddFault(project.ID, hbapi.Fault{Klass: "RuntimeError"})
+	}
+
+	client := newClient(fake)
+	first, err := client.Faults.List(context.Background(), project.ID, hbapi.FaultListOptions{})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(first.Results) != 2 {
+		t.Errorf("expected 2 faults on the first page, got %d", len(first.Results))
+	}
+	if first.Links.Next == "" {
+		t.Error("expected a next link on the first page")
+	}
+	if first.Links.Prev != "" {
+		t.Errorf("expected no prev link on the first page, got %s", first.Links.Prev)
+	}
+
+	var count int
+	for _, err := range client.Faults.All(context.Background(), project.ID, hbapi.FaultListOptions{}) {
+		if err != nil {
+			t.Fatalf("All() error = %v", err)
+		}
+		count++
+	}
+	if count != 5 {
+		t.Errorf("expected 5 faults, got %d", count)
+	}
+}
+
+func TestServer_NotFound(t *testing.T) {
+	fake := NewTestServer(t)
+
+	_, err := newClient(fake).Projects.Get(context.Background(), 999)
+	if !errors.Is(err, hbapi.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -12,9 +12,20 @@ type InsightsService struct {
 
 // InsightsQueryRequest represents a request to query insights data
 type InsightsQueryRequest struct {
-	Query    string `json:"query"`
-	Ts       string `json:"ts,omitempty"`
-	Timezone string `json:"timezone,omitempty"`
+	Query    string             `json:"query"`
+	BadgerQL *BadgerQLBuilder   `json:"-"` // Rendered into Query when set; set Query or BadgerQL, not both
+	Ts       string             `json:"ts,omitempty"`
+	Range    *InsightsTimeRange `json:"-"`                  // Rendered into Ts when set; set Ts or Range, not both
+	Timezone string             `json:"timezone,omitempty"` // IANA name, e.g. Europe/Berlin
+}
+
+// resolve renders BadgerQL and Range into Query and Ts and validates Timezone
+func (r InsightsQueryRequest) resolve() (InsightsQueryRequest, error) {
+	r, err := r.resolveBadgerQL()
+	if err != nil {
+		return r, err
+	}
+	return r.resolveRange()
 }
 
 // InsightsQueryMeta represents metadata about an insights query response
@@ -33,6 +44,11 @@ type InsightsQueryError struct {
 	Message string `json:"message"`
 }
 
+// Error returns the message the query failed with
+func (e *InsightsQueryError) Error() string {
+	return "insights query failed: " + e.Message
+}
+
 // InsightsQueryResponse represents the response from an insights query
 type InsightsQueryResponse struct {
 	Results []map[string]interface{} `json:"results"`
@@ -46,6 +62,13 @@ type InsightsQueryResponse struct {
 //
 // POST /v2/projects/{projectID}/insights/queries
 func (i *InsightsService) Query(ctx context.Context, projectID int, request InsightsQueryRequest) (*InsightsQueryResponse, error) {
+	ctx = withOperation(ctx, "Insights.Query", projectID, nil)
+
+	request, err := request.resolve()
+	if err != nil {
+		return nil, err
+	}
+
 	path := fmt.Sprintf("/projects/%d/insights/queries", projectID)
 
 	req, err := i.client.newRequest(ctx, "POST", path, request)
@@ -0,0 +1,421 @@
+package honeybadgerapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QueryInto runs a query and decodes its results into a slice of T with
+// DecodeRows. An inline query error in the response is returned as an
+// *InsightsQueryError.
+func QueryInto[T any](ctx context.Context, insights *InsightsService, projectID int, request InsightsQueryRequest) ([]T, error) {
+	response, err := insights.Query(ctx, projectID, request)
+	if err != nil {
+		return nil, err
+	}
+	if response.Error != nil {
+		return nil, response.Error
+	}
+	return DecodeRows[T](response)
+}
+
+// DecodeRows decodes the rows of a query response into a slice of T, which
+// must be a struct. Columns are matched to fields by their badgerql tag:
+//
+//	type RequestStats struct {
+//	    Bucket   time.Time     `badgerql:"bin(1h)"`
+//	    Count    int64         `badgerql:"count"`
+//	    P95      time.Duration `badgerql:"p95,s"`
+//	    Endpoint *string       `badgerql:"endpoint,optional"`
+//	}
+//
+// Fields without a tag, and columns without a field, are ignored. A tagged
+// column that is not in the results is an error unless the tag has the
+// "optional" option. The column types in Meta.Schema are checked against the
+// field types before any row is decoded.
+//
+// Integer columns decode into any integer or float field, and ClickHouse's
+// quoted 64-bit integers are accepted. DateTime columns decode into
+// time.Time, using the column's time zone if it has one and UTC otherwise.
+// Numeric columns decode into time.Duration as milliseconds, unless the tag
+// has the "s", "us" or "ns" option; strings such as "1.5s" are also accepted.
+// Null values leave non-pointer fields at their zero value and set pointer
+// fields to nil.
+func DecodeRows[T any](response *InsightsQueryResponse) ([]T, error) {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot decode insights rows into %s: not a struct", typ)
+	}
+	columns, err := planInsightsColumns(typ, response.Meta)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]T, len(response.Results))
+	for i, result := range response.Results {
+		row := reflect.ValueOf(&rows[i]).Elem()
+		for _, col := range columns {
+			value, ok := result[col.name]
+			if !ok {
+				if col.optional {
+					continue
+				}
+				return nil, fmt.Errorf("row %d: column %q for field %s is missing", i, col.name, qualifiedField(typ, col.field))
+			}
+			if err := col.decode(row.FieldByIndex(col.index), value); err != nil {
+				return nil, fmt.Errorf("row %d: column %q into field %s: %w", i, col.name, qualifiedField(typ, col.field), err)
+			}
+		}
+	}
+	return rows, nil
+}
+
+// insightsColumn maps a result column to a struct field
+type insightsColumn struct {
+	name     string
+	field    string
+	index    []int
+	optional bool
+	unit     time.Duration // For time.Duration fields
+	dbType   string        // Column type from the schema, without Nullable or LowCardinality
+	location *time.Location
+}
+
+// planInsightsColumns matches the tagged fields of typ to the columns
+// described by meta and checks their types
+func planInsightsColumns(typ reflect.Type, meta InsightsQueryMeta) ([]insightsColumn, error) {
+	schema := map[string]string{}
+	for _, column := range meta.Schema {
+		name, _ := column["name"].(string)
+		dbType, _ := column["type"].(string)
+		if name != "" {
+			schema[name] = dbType
+		}
+	}
+	known := len(schema) > 0 || len(meta.Fields) > 0
+	for _, name := range meta.Fields {
+		if _, ok := schema[name]; !ok {
+			schema[name] = ""
+		}
+	}
+
+	var columns []insightsColumn
+	for _, field := range reflect.VisibleFields(typ) {
+		tag, ok := field.Tag.Lookup("badgerql")
+		if !ok || tag == "-" || !field.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		col := insightsColumn{name: name, field: field.Name, index: field.Index, unit: time.Millisecond}
+		if col.name == "" {
+			return nil, fmt.Errorf("field %s has an empty badgerql tag", qualifiedField(typ, field.Name))
+		}
+		for _, opt := range strings.Split(opts, ",") {
+			switch opt {
+			case "":
+			case "optional":
+				col.optional = true
+			case "s":
+				col.unit = time.Second
+			case "ms":
+				col.unit = time.Millisecond
+			case "us":
+				col.unit = time.Microsecond
+			case "ns":
+				col.unit = time.Nanosecond
+			default:
+				return nil, fmt.Errorf("field %s has unknown badgerql tag option %q", qualifiedField(typ, field.Name), opt)
+			}
+		}
+
+		dbType, ok := schema[name]
+		if known && !ok {
+			if col.optional {
+				continue
+			}
+			return nil, fmt.Errorf("column %q for field %s is not in the query results", name, qualifiedField(typ, field.Name))
+		}
+		col.dbType, col.location = parseColumnType(dbType)
+		if err := checkColumnType(col.dbType, field.Type); err != nil {
+			return nil, fmt.Errorf("column %q for field %s: %w", name, qualifiedField(typ, field.Name), err)
+		}
+		columns = append(columns, col)
+	}
+	return columns, nil
+}
+
+// qualifiedField returns Type.Field, or just the field name for anonymous structs
+func qualifiedField(typ reflect.Type, field string) string {
+	if typ.Name() == "" {
+		return field
+	}
+	return typ.Name() + "." + field
+}
+
+// parseColumnType strips Nullable and LowCardinality wrappers from a
+// ClickHouse type and returns the time zone of DateTime columns
+func parseColumnType(dbType string) (string, *time.Location) {
+	for _, wrapper := range []string{"Nullable(", "LowCardinality("} {
+		for strings.HasPrefix(dbType, wrapper) && strings.HasSuffix(dbType, ")") {
+			dbType = dbType[len(wrapper) : len(dbType)-1]
+		}
+	}
+
+	location := time.UTC
+	if strings.HasPrefix(dbType, "DateTime") {
+		// DateTime('Europe/Berlin') or DateTime64(3, 'Europe/Berlin')
+		if start := strings.Index(dbType, "'"); start >= 0 {
+			if end := strings.LastIndex(dbType, "'"); end > start {
+				if loc, err := time.LoadLocation(dbType[start+1 : end]); err == nil {
+					location = loc
+				}
+			}
+		}
+	}
+	return dbType, location
+}
+
+// columnKind groups ClickHouse types by the Go values they decode into
+func columnKind(dbType string) string {
+	switch {
+	case dbType == "":
+		return ""
+	case strings.HasPrefix(dbType, "DateTime") || strings.HasPrefix(dbType, "Date"):
+		return "time"
+	case strings.HasPrefix(dbType, "Int") || strings.HasPrefix(dbType, "UInt"):
+		return "int"
+	case strings.HasPrefix(dbType, "Float") || strings.HasPrefix(dbType, "Decimal"):
+		return "float"
+	case dbType == "Bool":
+		return "bool"
+	case dbType == "String" || dbType == "UUID" || strings.HasPrefix(dbType, "FixedString") || strings.HasPrefix(dbType, "Enum"):
+		return "string"
+	}
+	return "other"
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// checkColumnType reports whether a column of dbType can decode into typ.
+// Columns of unknown type are checked row by row instead.
+func checkColumnType(dbType string, typ reflect.Type) error {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	kind := columnKind(dbType)
+	if kind == "" || kind == "other" || typ.Kind() == reflect.Interface {
+		return nil
+	}
+
+	var ok bool
+	switch {
+	case typ == timeType:
+		ok = kind == "time" || kind == "string"
+	case typ == durationType:
+		ok = kind == "int" || kind == "float" || kind == "string"
+	case typ.Kind() == reflect.String:
+		ok = kind == "string" || kind == "time"
+	case typ.Kind() == reflect.Bool:
+		ok = kind == "bool" || kind == "int"
+	case isIntKind(typ.Kind()) || isUintKind(typ.Kind()):
+		ok = kind == "int"
+	case typ.Kind() == reflect.Float32 || typ.Kind() == reflect.Float64:
+		ok = kind == "int" || kind == "float"
+	}
+	if !ok {
+		return fmt.Errorf("cannot decode %s column into %s", dbType, typ)
+	}
+	return nil
+}
+
+func isIntKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Int64
+}
+
+func isUintKind(k reflect.Kind) bool {
+	return k >= reflect.Uint && k <= reflect.Uintptr
+}
+
+// decode converts a JSON-decoded value and stores it in dst
+func (col insightsColumn) decode(dst reflect.Value, value interface{}) error {
+	if value == nil {
+		dst.SetZero()
+		return nil
+	}
+	if dst.Kind() == reflect.Pointer {
+		elem := reflect.New(dst.Type().Elem())
+		if err := col.decode(elem.Elem(), value); err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+	}
+
+	switch {
+	case dst.Kind() == reflect.Interface:
+		dst.Set(reflect.ValueOf(value))
+		return nil
+	case dst.Type() == timeType:
+		t, err := col.decodeTime(value)
+		if err != nil {
+			return err
+		}
+		dst.Set(reflect.ValueOf(t))
+		return nil
+	case dst.Type() == durationType:
+		d, err := col.decodeDuration(value)
+		if err != nil {
+			return err
+		}
+		dst.SetInt(int64(d))
+		return nil
+	}
+
+	switch {
+	case dst.Kind() == reflect.String:
+		s, ok := value.(string)
+		if !ok {
+			return mistyped(value, dst.Type())
+		}
+		dst.SetString(s)
+	case dst.Kind() == reflect.Bool:
+		switch v := value.(type) {
+		case bool:
+			dst.SetBool(v)
+		case float64:
+			if v != 0 && v != 1 {
+				return mistyped(value, dst.Type())
+			}
+			dst.SetBool(v == 1)
+		default:
+			return mistyped(value, dst.Type())
+		}
+	case isIntKind(dst.Kind()):
+		n, err := decodeInt(value)
+		if err != nil {
+			return err
+		}
+		if dst.OverflowInt(n) {
+			return fmt.Errorf("value %d overflows %s", n, dst.Type())
+		}
+		dst.SetInt(n)
+	case isUintKind(dst.Kind()):
+		n, err := decodeUint(value)
+		if err != nil {
+			return err
+		}
+		if dst.OverflowUint(n) {
+			return fmt.Errorf("value %d overflows %s", n, dst.Type())
+		}
+		dst.SetUint(n)
+	case dst.Kind() == reflect.Float32 || dst.Kind() == reflect.Float64:
+		f, err := decodeFloat(value)
+		if err != nil {
+			return err
+		}
+		dst.SetFloat(f)
+	default:
+		// Arrays, maps and other composite values round-trip through JSON
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(data, dst.Addr().Interface())
+	}
+	return nil
+}
+
+func mistyped(value interface{}, typ reflect.Type) error {
+	return fmt.Errorf("cannot decode %T value %v into %s", value, value, typ)
+}
+
+// decodeInt accepts JSON numbers with no fractional part and quoted integers
+func decodeInt(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case float64:
+		if v != math.Trunc(v) || v < math.MinInt64 || v >= math.MaxInt64 {
+			return 0, fmt.Errorf("value %v is not an integer", v)
+		}
+		return int64(v), nil
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	case json.Number:
+		return v.Int64()
+	}
+	return 0, mistyped(value, reflect.TypeOf(int64(0)))
+}
+
+func decodeUint(value interface{}) (uint64, error) {
+	switch v := value.(type) {
+	case float64:
+		if v != math.Trunc(v) || v < 0 || v >= math.MaxUint64 {
+			return 0, fmt.Errorf("value %v is not an unsigned integer", v)
+		}
+		return uint64(v), nil
+	case string:
+		return strconv.ParseUint(v, 10, 64)
+	case json.Number:
+		return strconv.ParseUint(v.String(), 10, 64)
+	}
+	return 0, mistyped(value, reflect.TypeOf(uint64(0)))
+}
+
+func decodeFloat(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	case json.Number:
+		return v.Float64()
+	}
+	return 0, mistyped(value, reflect.TypeOf(float64(0)))
+}
+
+// insightsTimeLayouts are the timestamp formats returned for DateTime and Date columns
+var insightsTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+// decodeTime parses timestamps, or Unix seconds for numeric values
+func (col insightsColumn) decodeTime(value interface{}) (time.Time, error) {
+	switch v := value.(type) {
+	case string:
+		for _, layout := range insightsTimeLayouts {
+			if t, err := time.ParseInLocation(layout, v, col.location); err == nil {
+				return t, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("cannot parse %q as a timestamp", v)
+	case float64:
+		sec, frac := math.Modf(v)
+		return time.Unix(int64(sec), int64(frac*1e9)).In(col.location), nil
+	}
+	return time.Time{}, mistyped(value, timeType)
+}
+
+// decodeDuration converts numbers in the column's unit, or duration strings
+func (col insightsColumn) decodeDuration(value interface{}) (time.Duration, error) {
+	switch v := value.(type) {
+	case float64:
+		return time.Duration(v * float64(col.unit)), nil
+	case string:
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			return time.Duration(n * float64(col.unit)), nil
+		}
+		return time.ParseDuration(v)
+	}
+	return 0, mistyped(value, durationType)
+}
@@ -0,0 +1,257 @@
+package honeybadgerapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func decodeTestResponse(t *testing.T, body string) *InsightsQueryResponse {
+	t.Helper()
+	var response InsightsQueryResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return &response
+}
+
+const decodeRowsResponse = `{
+	"results": [
+		{"ts": "2024-01-01 00:00:00", "count": "18446744073709551615", "avg": 12.5, "p95": 250, "name": "web", "ok": 1, "region": null, "tags": ["a", "b"]},
+		{"ts": "2024-01-01T01:00:00Z", "count": "15", "avg": 3, "p95": "1.5s", "name": "api", "ok": true, "region": "eu", "tags": []}
+	],
+	"meta": {
+		"fields": ["ts", "count", "avg", "p95", "name", "ok", "region", "tags"],
+		"schema": [
+			{"name": "ts", "type": "DateTime('UTC')"},
+			{"name": "count", "type": "UInt64"},
+			{"name": "avg", "type": "Float64"},
+			{"name": "p95", "type": "Float64"},
+			{"name": "name", "type": "LowCardinality(String)"},
+			{"name": "ok", "type": "UInt8"},
+			{"name": "region", "type": "Nullable(String)"},
+			{"name": "tags", "type": "Array(String)"}
+		]
+	}
+}`
+
+func TestDecodeRows(t *testing.T) {
+	type row struct {
+		Timestamp time.Time     `badgerql:"ts"`
+		Count     uint64        `badgerql:"count"`
+		Avg       float64       `badgerql:"avg"`
+		P95       time.Duration `badgerql:"p95"`
+		Name      string        `badgerql:"name"`
+		OK        bool          `badgerql:"ok"`
+		Region    *string       `badgerql:"region"`
+		Tags      []string      `badgerql:"tags"`
+		Missing   string        `badgerql:"missing,optional"`
+		Ignored   string
+	}
+
+	rows, err := DecodeRows[row](decodeTestResponse(t, decodeRowsResponse))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+
+	first, second := rows[0], rows[1]
+	if expected := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC); !first.Timestamp.Equal(expected) {
+		t.Errorf("expected timestamp %s, got %s", expected, first.Timestamp)
+	}
+	if first.Count != 18446744073709551615 {
+		t.Errorf("expected max uint64 count, got %d", first.Count)
+	}
+	if first.Avg != 12.5 {
+		t.Errorf("expected avg 12.5, got %v", first.Avg)
+	}
+	if first.P95 != 250*time.Millisecond {
+		t.Errorf("expected p95 250ms, got %s", first.P95)
+	}
+	if second.P95 != 1500*time.Millisecond {
+		t.Errorf("expected p95 1.5s, got %s", second.P95)
+	}
+	if first.Name != "web" || !first.OK || !second.OK {
+		t.Errorf("unexpected name or ok: %+v", first)
+	}
+	if first.Region != nil {
+		t.Errorf("expected nil region, got %q", *first.Region)
+	}
+	if second.Region == nil || *second.Region != "eu" {
+		t.Errorf("expected region eu, got %v", second.Region)
+	}
+	if len(first.Tags) != 2 || first.Tags[1] != "b" {
+		t.Errorf("expected tags [a b], got %v", first.Tags)
+	}
+	if second.Count != 15 {
+		t.Errorf("expected count 15, got %d", second.Count)
+	}
+}
+
+func TestDecodeRows_TimeZoneAndUnits(t *testing.T) {
+	response := decodeTestResponse(t, `{
+		"results": [{"ts": "2024-06-01 12:00:00.250", "duration": 2, "n": 7}],
+		"meta": {"schema": [
+			{"name": "ts", "type": "DateTime64(3, 'Europe/Berlin')"},
+			{"name": "duration", "type": "Int64"},
+			{"name": "n", "type": "Int32"}
+		]}
+	}`)
+
+	type row struct {
+		Timestamp time.Time     `badgerql:"ts"`
+		Duration  time.Duration `badgerql:"duration,s"`
+		N         int8          `badgerql:"n"`
+		Any       interface{}   `badgerql:"n"`
+	}
+	rows, err := DecodeRows[row](response)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := time.Date(2024, 6, 1, 10, 0, 0, 250e6, time.UTC); !rows[0].Timestamp.Equal(expected) {
+		t.Errorf("expected %s, got %s", expected, rows[0].Timestamp)
+	}
+	if rows[0].Duration != 2*time.Second {
+		t.Errorf("expected 2s, got %s", rows[0].Duration)
+	}
+	if rows[0].N != 7 || rows[0].Any != 7.0 {
+		t.Errorf("expected 7, got %d and %v", rows[0].N, rows[0].Any)
+	}
+}
+
+func TestDecodeRows_Errors(t *testing.T) {
+	response := decodeTestResponse(t, decodeRowsResponse)
+
+	tests := []struct {
+		name     string
+		decode   func() error
+		expected string
+	}{
+		{
+			name: "missing column",
+			decode: func() error {
+				_, err := DecodeRows[struct {
+					Host string `badgerql:"host"`
+				}](response)
+				return err
+			},
+			expected: `column "host" for field Host is not in the query results`,
+		},
+		{
+			name: "mistyped column",
+			decode: func() error {
+				_, err := DecodeRows[struct {
+					Name int `badgerql:"name"`
+				}](response)
+				return err
+			},
+			expected: `cannot decode String column into int`,
+		},
+		{
+			name: "fractional integer",
+			decode: func() error {
+				_, err := DecodeRows[struct {
+					Avg int `badgerql:"avg"`
+				}](decodeTestResponse(t, `{"results": [{"avg": 1.5}]}`))
+				return err
+			},
+			expected: `row 0: column "avg" into field Avg: value 1.5 is not an integer`,
+		},
+		{
+			name: "overflow",
+			decode: func() error {
+				_, err := DecodeRows[struct {
+					Count uint8 `badgerql:"count"`
+				}](response)
+				return err
+			},
+			expected: `overflows uint8`,
+		},
+		{
+			name: "missing value",
+			decode: func() error {
+				_, err := DecodeRows[struct {
+					Host string `badgerql:"host"`
+				}](decodeTestResponse(t, `{"results": [{"name": "web"}]}`))
+				return err
+			},
+			expected: `row 0: column "host" for field Host is missing`,
+		},
+		{
+			name: "not a struct",
+			decode: func() error {
+				_, err := DecodeRows[string](response)
+				return err
+			},
+			expected: `not a struct`,
+		},
+		{
+			name: "unknown tag option",
+			decode: func() error {
+				_, err := DecodeRows[struct {
+					Name string `badgerql:"name,nullable"`
+				}](response)
+				return err
+			},
+			expected: `unknown badgerql tag option "nullable"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.decode()
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("expected error containing %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestQueryInto(t *testing.T) {
+	inlineError := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if inlineError {
+			_, _ = w.Write([]byte(`{"results": [], "meta": {}, "error": {"message": "unknown field foo"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(decodeRowsResponse))
+	}))
+	defer server.Close()
+
+	client := NewClient().WithBaseURL(server.URL).WithAuthToken("test-token")
+
+	type row struct {
+		Name  string `badgerql:"name"`
+		Count int64  `badgerql:"count"`
+	}
+	_, err := QueryInto[row](context.Background(), client.Insights, 123, InsightsQueryRequest{Query: "stats count() by name"})
+	if err == nil || !strings.Contains(err.Error(), "value out of range") {
+		t.Errorf("expected a range error for max uint64 in int64, got %v", err)
+	}
+
+	type unsignedRow struct {
+		Name  string `badgerql:"name"`
+		Count uint64 `badgerql:"count"`
+	}
+	rows, err := QueryInto[unsignedRow](context.Background(), client.Insights, 123, InsightsQueryRequest{Query: "stats count() by name"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 2 || rows[1].Name != "api" || rows[1].Count != 15 {
+		t.Errorf("unexpected rows: %+v", rows)
+	}
+
+	inlineError = true
+	_, err = QueryInto[unsignedRow](context.Background(), client.Insights, 123, InsightsQueryRequest{Query: "fields foo"})
+	var queryErr *InsightsQueryError
+	if !errors.As(err, &queryErr) || queryErr.Message != "unknown field foo" {
+		t.Errorf("expected an InsightsQueryError, got %v", err)
+	}
+}
@@ -0,0 +1,270 @@
+package honeybadgerapi
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ExportFormat is a file format Insights results can be exported as
+type ExportFormat string
+
+const (
+	ExportCSV      ExportFormat = "csv"      // Comma-separated values with a header row
+	ExportNDJSON   ExportFormat = "ndjson"   // One JSON object per line
+	ExportMarkdown ExportFormat = "markdown" // GitHub-flavored Markdown table
+)
+
+// InsightsExporter writes Insights rows to w as they are added, so results
+// can be exported without holding them in memory. Columns follow the order
+// of the fields it was created with, usually Meta.Fields; if there are none,
+// the sorted keys of the first row are used.
+//
+// Values are rendered the same way in CSV and Markdown: nulls and missing
+// values as empty cells, numbers without exponents, and nested arrays and
+// objects as compact JSON. NDJSON keeps the JSON values, with null for
+// missing ones.
+//
+// Call Close to write the header of an empty result and flush buffered output.
+type InsightsExporter struct {
+	format  ExportFormat
+	w       *bufio.Writer
+	csv     *csv.Writer
+	fields  []string
+	started bool
+	rows    int
+}
+
+// NewInsightsExporter returns an exporter that writes rows to w in format
+func NewInsightsExporter(w io.Writer, format ExportFormat, fields []string) (*InsightsExporter, error) {
+	e := &InsightsExporter{format: format, w: bufio.NewWriter(w), fields: fields}
+	switch format {
+	case ExportCSV:
+		e.csv = csv.NewWriter(e.w)
+	case ExportNDJSON, ExportMarkdown:
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+	return e, nil
+}
+
+// Rows returns the number of rows written so far
+func (e *InsightsExporter) Rows() int {
+	return e.rows
+}
+
+// WriteRow writes one row
+func (e *InsightsExporter) WriteRow(row map[string]interface{}) error {
+	if !e.started {
+		if len(e.fields) == 0 {
+			e.fields = sortedKeys(row)
+		}
+		if err := e.writeHeader(); err != nil {
+			return err
+		}
+	}
+
+	var err error
+	switch e.format {
+	case ExportCSV:
+		err = e.csv.Write(e.cells(row))
+	case ExportNDJSON:
+		err = e.writeJSONRow(row)
+	case ExportMarkdown:
+		err = e.writeMarkdownRow(e.cells(row))
+	}
+	if err != nil {
+		return err
+	}
+	e.rows++
+	return nil
+}
+
+// WriteRows writes each of rows
+func (e *InsightsExporter) WriteRows(rows []map[string]interface{}) error {
+	for _, row := range rows {
+		if err := e.WriteRow(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close writes the header if no rows were written and flushes the output.
+// It does not close the underlying writer.
+func (e *InsightsExporter) Close() error {
+	if !e.started && len(e.fields) > 0 {
+		if err := e.writeHeader(); err != nil {
+			return err
+		}
+	}
+	if e.csv != nil {
+		e.csv.Flush()
+		if err := e.csv.Error(); err != nil {
+			return err
+		}
+	}
+	return e.w.Flush()
+}
+
+func (e *InsightsExporter) writeHeader() error {
+	e.started = true
+	switch e.format {
+	case ExportCSV:
+		return e.csv.Write(e.fields)
+	case ExportMarkdown:
+		if err := e.writeMarkdownRow(e.fields); err != nil {
+			return err
+		}
+		separators := make([]string, len(e.fields))
+		for i := range separators {
+			separators[i] = "---"
+		}
+		_, err := e.w.WriteString("| " + strings.Join(separators, " | ") + " |\n")
+		return err
+	}
+	return nil
+}
+
+// cells renders the values of row in field order
+func (e *InsightsExporter) cells(row map[string]interface{}) []string {
+	cells := make([]string, len(e.fields))
+	for i, field := range e.fields {
+		cells[i] = formatExportValue(row[field])
+	}
+	return cells
+}
+
+func (e *InsightsExporter) writeJSONRow(row map[string]interface{}) error {
+	// Build the object by hand to keep the field order
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range e.fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := marshalCompact(field)
+		if err != nil {
+			return err
+		}
+		value, err := marshalCompact(row[field])
+		if err != nil {
+			return fmt.Errorf("field %q: %w", field, err)
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteString("}\n")
+	_, err := e.w.Write(buf.Bytes())
+	return err
+}
+
+// markdownEscaper escapes characters that would break a table cell
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`|`, `\|`,
+	"\r\n", "<br>",
+	"\n", "<br>",
+	"\r", "<br>",
+)
+
+func (e *InsightsExporter) writeMarkdownRow(cells []string) error {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		escaped[i] = markdownEscaper.Replace(cell)
+	}
+	_, err := e.w.WriteString("| " + strings.Join(escaped, " | ") + " |\n")
+	return err
+}
+
+// formatExportValue renders a JSON-decoded value as a CSV or Markdown cell
+func formatExportValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case json.Number:
+		return v.String()
+	}
+	data, err := marshalCompact(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// marshalCompact encodes v as JSON without escaping HTML characters
+func marshalCompact(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+func sortedKeys(row map[string]interface{}) []string {
+	keys := make([]string, 0, len(row))
+	for key := range row {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Export writes the rows of a query response to w in format
+func (r *InsightsQueryResponse) Export(w io.Writer, format ExportFormat) error {
+	e, err := NewInsightsExporter(w, format, r.Meta.Fields)
+	if err != nil {
+		return err
+	}
+	if err := e.WriteRows(r.Results); err != nil {
+		return err
+	}
+	return e.Close()
+}
+
+// Export writes every remaining row of the pager to w in format, one page
+// at a time, and returns the number of rows written. Columns follow the
+// fields of the first page. Rows written before an error are flushed.
+func (p *InsightsPager) Export(ctx context.Context, w io.Writer, format ExportFormat) (int, error) {
+	e, err := NewInsightsExporter(w, format, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	for p.More() {
+		page, err := p.NextPage(ctx)
+		if err == nil {
+			if !e.started && len(e.fields) == 0 && p.Meta() != nil {
+				e.fields = p.Meta().Fields
+			}
+			err = e.WriteRows(page)
+		}
+		if err != nil {
+			_ = e.Close()
+			return e.Rows(), err
+		}
+	}
+	return e.Rows(), e.Close()
+}
+
+// Export pages through every row of a query and writes it to w in format,
+// holding one page in memory at a time. It returns the number of rows written.
+func (i *InsightsService) Export(ctx context.Context, w io.Writer, projectID int, request InsightsQueryRequest, format ExportFormat, options InsightsPageOptions) (int, error) {
+	return i.QueryPager(projectID, request, options).Export(ctx, w, format)
+}
@@ -0,0 +1,127 @@
+package honeybadgerapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func exportTestResponse() *InsightsQueryResponse {
+	return &InsightsQueryResponse{
+		Results: []map[string]interface{}{
+			{"name": "web", "count": 1500000.0, "ratio": 0.25, "ok": true, "tags": []interface{}{"a", "b"}, "region": nil},
+			{"name": "a|b, \"c\"\nd", "count": 2.0, "ratio": nil, "ok": false, "tags": map[string]interface{}{"k": "<v>"}},
+		},
+		Meta: InsightsQueryMeta{Fields: []string{"name", "count", "ratio", "ok", "tags", "region"}},
+	}
+}
+
+func TestInsightsQueryResponse_Export(t *testing.T) {
+	tests := []struct {
+		format   ExportFormat
+		expected string
+	}{
+		{
+			format: ExportCSV,
+			expected: "name,count,ratio,ok,tags,region\n" +
+				"web,1500000,0.25,true,\"[\"\"a\"\",\"\"b\"\"]\",\n" +
+				"\"a|b, \"\"c\"\"\nd\",2,,false,\"{\"\"k\"\":\"\"<v>\"\"}\",\n",
+		},
+		{
+			format: ExportNDJSON,
+			expected: `{"name":"web","count":1500000,"ratio":0.25,"ok":true,"tags":["a","b"],"region":null}` + "\n" +
+				`{"name":"a|b, \"c\"\nd","count":2,"ratio":null,"ok":false,"tags":{"k":"<v>"},"region":null}` + "\n",
+		},
+		{
+			format: ExportMarkdown,
+			expected: "| name | count | ratio | ok | tags | region |\n" +
+				"| --- | --- | --- | --- | --- | --- |\n" +
+				"| web | 1500000 | 0.25 | true | [\"a\",\"b\"] |  |\n" +
+				"| a\\|b, \"c\"<br>d | 2 |  | false | {\"k\":\"<v>\"} |  |\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := exportTestResponse().Export(&buf, tt.format); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.expected, buf.String())
+			}
+		})
+	}
+}
+
+func TestInsightsExporter(t *testing.T) {
+	if _, err := NewInsightsExporter(&bytes.Buffer{}, "xlsx", nil); err == nil {
+		t.Error("expected an error for an unsupported format")
+	}
+
+	// Empty results still get a header
+	var buf bytes.Buffer
+	e, _ := NewInsightsExporter(&buf, ExportMarkdown, []string{"a", "b"})
+	if err := e.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := "| a | b |\n| --- | --- |\n"; buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+
+	// Without fields, columns come from the sorted keys of the first row
+	buf.Reset()
+	e, _ = NewInsightsExporter(&buf, ExportCSV, nil)
+	_ = e.WriteRow(map[string]interface{}{"b": 1.0, "a": "x"})
+	_ = e.WriteRow(map[string]interface{}{"a": "y", "c": "ignored"})
+	if err := e.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := "a,b\nx,1\ny,\n"; buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+	if e.Rows() != 2 {
+		t.Errorf("expected 2 rows, got %d", e.Rows())
+	}
+}
+
+func TestInsightsExport_Paged(t *testing.T) {
+	server := newInsightsPagingServer(t, 7)
+	client := NewClient().WithBaseURL(server.URL).WithAuthToken("test-token")
+
+	var buf bytes.Buffer
+	n, err := client.Insights.Export(context.Background(), &buf, 123, InsightsQueryRequest{Query: "fields n"}, ExportCSV, InsightsPageOptions{PageSize: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 7 {
+		t.Errorf("expected 7 rows, got %d", n)
+	}
+	if expected := "n\n0\n1\n2\n3\n4\n5\n6\n"; buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+	if len(server.requests) != 3 {
+		t.Errorf("expected 3 requests, got %d", len(server.requests))
+	}
+
+	if _, err := client.Insights.Export(context.Background(), &buf, 123, InsightsQueryRequest{Query: "fields n"}, "xlsx", InsightsPageOptions{}); err == nil {
+		t.Error("expected an error for an unsupported format")
+	}
+	if len(server.requests) != 3 {
+		t.Errorf("expected no request for an unsupported format, got %d requests", len(server.requests))
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestInsightsExport_WriteError(t *testing.T) {
+	err := exportTestResponse().Export(failingWriter{}, ExportNDJSON)
+	if err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Errorf("expected a write error, got %v", err)
+	}
+}
@@ -0,0 +1,232 @@
+package honeybadgerapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"strconv"
+	"strings"
+)
+
+// DefaultInsightsPageSize is the number of rows QueryPager requests at a time when none is set
+const DefaultInsightsPageSize = 1000
+
+// InsightsPageOptions controls how a query is paged
+type InsightsPageOptions struct {
+	PageSize int // Rows per request; defaults to DefaultInsightsPageSize
+	MaxRows  int // Stop after this many rows; zero or less means no limit
+}
+
+// InsightsPager fetches the complete results of a query one page at a time
+// by appending a limit and offset to it. The time window of the first page
+// is reused for every later page, so rows that arrive while paging do not
+// shift the offsets. A trailing limit in the query caps the total number of
+// rows instead of the page size.
+//
+// An InsightsPager is not safe for concurrent use.
+type InsightsPager struct {
+	service   *InsightsService
+	projectID int
+	request   InsightsQueryRequest
+	err       error // Set if the request could not be prepared for paging
+	pageSize  int
+	maxRows   int
+	offset    int
+	done      bool
+	meta      *InsightsQueryMeta
+}
+
+// QueryPager returns a pager over every row of a query
+func (i *InsightsService) QueryPager(projectID int, request InsightsQueryRequest, options InsightsPageOptions) *InsightsPager {
+	p := &InsightsPager{service: i, projectID: projectID, pageSize: options.PageSize, maxRows: options.MaxRows}
+	if p.pageSize < 1 {
+		p.pageSize = DefaultInsightsPageSize
+	}
+
+	request, err := request.resolve()
+	if err != nil {
+		p.err = err
+		return p
+	}
+	query, limit, err := stripTrailingLimit(request.Query)
+	if err != nil {
+		p.err = err
+		return p
+	}
+	if limit > 0 && (p.maxRows < 1 || limit < p.maxRows) {
+		p.maxRows = limit
+	}
+	request.Query = query
+	p.request = request
+	return p
+}
+
+// stripTrailingLimit removes a final limit stage from a query and returns its value
+func stripTrailingLimit(query string) (string, int, error) {
+	stages, err := splitBadgerQLStages(query)
+	if err != nil {
+		return "", 0, err
+	}
+	last := len(stages) - 1
+	command, args, _ := strings.Cut(stages[last], " ")
+	if !strings.EqualFold(command, "limit") {
+		return strings.Join(stages, "\n| "), 0, nil
+	}
+	if last == 0 {
+		return "", 0, errors.New("query has no stages besides limit")
+	}
+
+	limit, err := strconv.Atoi(args)
+	if err != nil || limit < 1 {
+		return "", 0, fmt.Errorf("cannot page a query ending in %q; remove the limit and set InsightsPageOptions instead", stages[last])
+	}
+	return strings.Join(stages[:last], "\n| "), limit, nil
+}
+
+// More reports whether there may be more rows to fetch
+func (p *InsightsPager) More() bool {
+	return !p.done
+}
+
+// Meta returns the metadata of the first page, or nil before it is fetched.
+// Its StartAt and EndAt are the window every page is queried over.
+func (p *InsightsPager) Meta() *InsightsQueryMeta {
+	return p.meta
+}
+
+// NextPage fetches the next page of rows. It returns an empty slice once
+// all rows have been fetched.
+func (p *InsightsPager) NextPage(ctx context.Context) ([]map[string]interface{}, error) {
+	if p.done {
+		return nil, nil
+	}
+	if p.err != nil {
+		p.done = true
+		return nil, p.err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	limit := p.pageSize
+	if p.maxRows > 0 && p.maxRows-p.offset < limit {
+		limit = p.maxRows - p.offset
+	}
+
+	request := p.request
+	request.Query = fmt.Sprintf("%s\n| limit %d offset %d", p.request.Query, limit, p.offset)
+	response, err := p.service.Query(ctx, p.projectID, request)
+	if err != nil {
+		return nil, err
+	}
+	if response.Error != nil {
+		p.done = true
+		return nil, response.Error
+	}
+
+	if p.meta == nil {
+		p.meta = &response.Meta
+		// Pin the window so later pages see the same rows
+		start, startErr := response.Meta.StartTime()
+		end, endErr := response.Meta.EndTime()
+		if startErr == nil && endErr == nil && end.After(start) {
+			p.request.Ts = Between(start, end).String()
+		}
+	}
+
+	rows := response.Results
+	if len(rows) > limit {
+		rows = rows[:limit]
+	}
+	p.offset += len(rows)
+
+	switch {
+	case len(rows) == 0:
+		p.done = true
+	case p.maxRows > 0 && p.offset >= p.maxRows:
+		p.done = true
+	case response.Meta.TotalRows > 0:
+		// The server may return fewer rows than asked for, so a short page
+		// only ends paging when the total is unknown
+		p.done = p.offset >= response.Meta.TotalRows
+	default:
+		p.done = len(rows) < limit
+	}
+	return rows, nil
+}
+
+// All returns an iterator over every row. Iteration stops after the first
+// error, which is yielded with a nil row.
+func (p *InsightsPager) All(ctx context.Context) iter.Seq2[map[string]interface{}, error] {
+	return func(yield func(map[string]interface{}, error) bool) {
+		for p.More() {
+			page, err := p.NextPage(ctx)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			for _, row := range page {
+				if !yield(row, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Stream returns an iterator over every row of a query, holding one page in
+// memory at a time.
+func (i *InsightsService) Stream(ctx context.Context, projectID int, request InsightsQueryRequest, options InsightsPageOptions) iter.Seq2[map[string]interface{}, error] {
+	return i.QueryPager(projectID, request, options).All(ctx)
+}
+
+// QueryAll fetches every row of a query into a single response. Its Meta is
+// that of the first page with Rows set to the number of rows fetched. Use
+// Stream for result sets that should not be held in memory at once.
+func (i *InsightsService) QueryAll(ctx context.Context, projectID int, request InsightsQueryRequest, options InsightsPageOptions) (*InsightsQueryResponse, error) {
+	pager := i.QueryPager(projectID, request, options)
+
+	response := &InsightsQueryResponse{Results: []map[string]interface{}{}}
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		response.Results = append(response.Results, page...)
+	}
+
+	if meta := pager.Meta(); meta != nil {
+		response.Meta = *meta
+	}
+	response.Meta.Rows = len(response.Results)
+	return response, nil
+}
+
+// StreamInto returns an iterator over every row of a query decoded into T
+// as described by DecodeRows. Iteration stops after the first error.
+func StreamInto[T any](ctx context.Context, insights *InsightsService, projectID int, request InsightsQueryRequest, options InsightsPageOptions) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		pager := insights.QueryPager(projectID, request, options)
+		for pager.More() {
+			page, err := pager.NextPage(ctx)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			rows, err := DecodeRows[T](&InsightsQueryResponse{Results: page, Meta: *pager.Meta()})
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, row := range rows {
+				if !yield(row, nil) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package honeybadgerapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// insightsPagingServer serves total rows numbered from 0, honoring the limit
+// and offset appended to each query, and records the requests it receives.
+// Set pageCap to return at most that many rows per response, and omitTotal
+// to leave total_rows out of the metadata.
+type insightsPagingServer struct {
+	*httptest.Server
+	pageCap   int
+	omitTotal bool
+	mu        sync.Mutex
+	requests  []InsightsQueryRequest
+}
+
+var limitOffsetPattern = regexp.MustCompile(`\n\| limit (\d+) offset (\d+)$`)
+
+func newInsightsPagingServer(t *testing.T, total int) *insightsPagingServer {
+	s := &insightsPagingServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request InsightsQueryRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		s.mu.Lock()
+		s.requests = append(s.requests, request)
+		s.mu.Unlock()
+
+		match := limitOffsetPattern.FindStringSubmatch(request.Query)
+		if match == nil {
+			http.Error(w, `{"errors": "missing limit"}`, http.StatusBadRequest)
+			return
+		}
+		limit, _ := strconv.Atoi(match[1])
+		offset, _ := strconv.Atoi(match[2])
+
+		if s.pageCap > 0 && s.pageCap < limit {
+			limit = s.pageCap
+		}
+
+		results := []map[string]interface{}{}
+		for n := offset; n < total && n < offset+limit; n++ {
+			results = append(results, map[string]interface{}{"n": n})
+		}
+		meta := map[string]interface{}{
+			"fields":     []string{"n"},
+			"schema":     []map[string]string{{"name": "n", "type": "UInt64"}},
+			"rows":       len(results),
+			"total_rows": total,
+			"start_at":   "2024-01-01T00:00:00Z",
+			"end_at":     "2024-01-02T00:00:00Z",
+		}
+		if s.omitTotal {
+			delete(meta, "total_rows")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"results": results, "meta": meta})
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestInsightsStream(t *testing.T) {
+	server := newInsightsPagingServer(t, 25)
+	client := NewClient().WithBaseURL(server.URL).WithAuthToken("test-token")
+
+	var got []int
+	for row, err := range client.Insights.Stream(context.Background(), 123, InsightsQueryRequest{Query: "fields n", Ts: "P1D"}, InsightsPageOptions{PageSize: 10}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got = append(got, int(row["n"].(float64)))
+	}
+	if len(got) != 25 || got[0] != 0 || got[24] != 24 {
+		t.Errorf("expected rows 0 to 24, got %v", got)
+	}
+
+	if len(server.requests) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(server.requests))
+	}
+	expected := []struct{ query, ts string }{
+		{"fields n\n| limit 10 offset 0", "P1D"},
+		{"fields n\n| limit 10 offset 10", "2024-01-01T00:00:00Z/2024-01-02T00:00:00Z"},
+		{"fields n\n| limit 10 offset 20", "2024-01-01T00:00:00Z/2024-01-02T00:00:00Z"},
+	}
+	for i, e := range expected {
+		if server.requests[i].Query != e.query {
+			t.Errorf("request %d: expected query %q, got %q", i, e.query, server.requests[i].Query)
+		}
+		if server.requests[i].Ts != e.ts {
+			t.Errorf("request %d: expected ts %q, got %q", i, e.ts, server.requests[i].Ts)
+		}
+	}
+}
+
+func TestInsightsQueryAll_Limits(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		options  InsightsPageOptions
+		rows     int
+		requests int
+	}{
+		{"exact multiple of page size", "fields n", InsightsPageOptions{PageSize: 5}, 20, 4},
+		{"trailing limit caps rows", "fields n | limit 7", InsightsPageOptions{PageSize: 5}, 7, 2},
+		{"max rows", "fields n", InsightsPageOptions{PageSize: 5, MaxRows: 12}, 12, 3},
+		{"smaller of limit and max rows", "fields n | limit 3", InsightsPageOptions{MaxRows: 12}, 3, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newInsightsPagingServer(t, 20)
+			client := NewClient().WithBaseURL(server.URL).WithAuthToken("test-token")
+
+			response, err := client.Insights.QueryAll(context.Background(), 123, InsightsQueryRequest{Query: tt.query}, tt.options)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(response.Results) != tt.rows || response.Meta.Rows != tt.rows {
+				t.Errorf("expected %d rows, got %d (meta %d)", tt.rows, len(response.Results), response.Meta.Rows)
+			}
+			if response.Meta.TotalRows != 20 {
+				t.Errorf("expected total rows 20, got %d", response.Meta.TotalRows)
+			}
+			if len(server.requests) != tt.requests {
+				t.Errorf("expected %d requests, got %d", tt.requests, len(server.requests))
+			}
+		})
+	}
+}
+
+func TestInsightsQueryAll_ServerCapsPageSize(t *testing.T) {
+	tests := []struct {
+		name      string
+		omitTotal bool
+		rows      int
+		requests  int
+	}{
+		// Short pages keep paging until the total is reached
+		{"with total rows", false, 20, 5},
+		// Without a total a short page is taken as the last one
+		{"without total rows", true, 4, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newInsightsPagingServer(t, 20)
+			server.pageCap = 4
+			server.omitTotal = tt.omitTotal
+			client := NewClient().WithBaseURL(server.URL).WithAuthToken("test-token")
+
+			response, err := client.Insights.QueryAll(context.Background(), 123, InsightsQueryRequest{Query: "fields n"}, InsightsPageOptions{PageSize: 10})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(response.Results) != tt.rows {
+				t.Errorf("expected %d rows, got %d", tt.rows, len(response.Results))
+			}
+			for i, row := range response.Results {
+				if n := int(row["n"].(float64)); n != i {
+					t.Errorf("expected row %d, got %d", i, n)
+				}
+			}
+			if len(server.requests) != tt.requests {
+				t.Errorf("expected %d requests, got %d", tt.requests, len(server.requests))
+			}
+		})
+	}
+}
+
+func TestInsightsQueryAll_Errors(t *testing.T) {
+	server := newInsightsPagingServer(t, 20)
+	client := NewClient().WithBaseURL(server.URL).WithAuthToken("test-token")
+
+	for _, query := range []string{"", "limit 5", "fields n | limit 5 offset 10", `fields "unterminated`} {
+		if _, err := client.Insights.QueryAll(context.Background(), 123, InsightsQueryRequest{Query: query}, InsightsPageOptions{}); err == nil {
+			t.Errorf("expected an error for %q", query)
+		}
+	}
+	if len(server.requests) != 0 {
+		t.Errorf("expected no requests, got %d", len(server.requests))
+	}
+}
+
+func TestStreamInto(t *testing.T) {
+	server := newInsightsPagingServer(t, 8)
+	client := NewClient().WithBaseURL(server.URL).WithAuthToken("test-token")
+
+	type row struct {
+		N uint64 `badgerql:"n"`
+	}
+	var got []string
+	request := InsightsQueryRequest{BadgerQL: BadgerQL().Fields(Field("n"))}
+	for r, err := range StreamInto[row](context.Background(), client.Insights, 123, request, InsightsPageOptions{PageSize: 3}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got = append(got, fmt.Sprint(r.N))
+		if len(got) == 5 {
+			break
+		}
+	}
+	if strings.Join(got, ",") != "0,1,2,3,4" {
+		t.Errorf("expected rows 0 to 4, got %v", got)
+	}
+	if len(server.requests) != 2 {
+		t.Errorf("expected 2 requests after stopping early, got %d", len(server.requests))
+	}
+}
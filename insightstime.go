@@ -0,0 +1,157 @@
+package honeybadgerapi
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// InsightsTimeRange is the time window of an Insights query, set as
+// InsightsQueryRequest.Range. Build ranges with Last, LastHours, LastDays,
+// Between, DayOf and Today; invalid ranges are reported when the query is sent.
+type InsightsTimeRange struct {
+	ts       string
+	location *time.Location // Time zone the range was built in, if any
+	err      error
+}
+
+// String returns the range as a ts value, e.g. PT6H or an ISO 8601 interval
+func (r *InsightsTimeRange) String() string {
+	return r.ts
+}
+
+// Last returns the window ending now and reaching back d, which must be a
+// positive whole number of seconds
+func Last(d time.Duration) *InsightsTimeRange {
+	if d <= 0 || d%time.Second != 0 {
+		return &InsightsTimeRange{err: fmt.Errorf("time range must be a positive whole number of seconds, got %s", d)}
+	}
+	return &InsightsTimeRange{ts: formatISODuration(d)}
+}
+
+// LastHours returns the window covering the last n hours
+func LastHours(n int) *InsightsTimeRange {
+	return Last(time.Duration(n) * time.Hour)
+}
+
+// LastDays returns the window covering the last n days
+func LastDays(n int) *InsightsTimeRange {
+	return Last(time.Duration(n) * 24 * time.Hour)
+}
+
+// formatISODuration renders d as an ISO 8601 duration, e.g. P2D, PT1H30M
+func formatISODuration(d time.Duration) string {
+	if d%(24*time.Hour) == 0 {
+		return "P" + strconv.FormatInt(int64(d/(24*time.Hour)), 10) + "D"
+	}
+
+	var b strings.Builder
+	b.WriteString("PT")
+	for _, unit := range []struct {
+		size   time.Duration
+		suffix string
+	}{
+		{time.Hour, "H"},
+		{time.Minute, "M"},
+		{time.Second, "S"},
+	} {
+		if n := d / unit.size; n > 0 {
+			b.WriteString(strconv.FormatInt(int64(n), 10) + unit.suffix)
+			d -= n * unit.size
+		}
+	}
+	return b.String()
+}
+
+// Between returns the window from start up to end. The times are sent in UTC,
+// so the window is exact regardless of the query's time zone.
+func Between(start, end time.Time) *InsightsTimeRange {
+	if !end.After(start) {
+		return &InsightsTimeRange{err: fmt.Errorf("time range end %s is not after start %s", end, start)}
+	}
+	return &InsightsTimeRange{ts: formatInsightsTime(start) + "/" + formatInsightsTime(end)}
+}
+
+func formatInsightsTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// DayOf returns the calendar day containing t in t's location, from midnight
+// to the following midnight. Days are 23 or 25 hours long across DST
+// changes. The request's Timezone defaults to t's location unless it is time.Local.
+func DayOf(t time.Time) *InsightsTimeRange {
+	loc := t.Location()
+	start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	end := time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+
+	r := Between(start, end)
+	r.location = loc
+	return r
+}
+
+// Today returns the current calendar day in loc, or in UTC if loc is nil
+func Today(loc *time.Location) *InsightsTimeRange {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return DayOf(time.Now().In(loc))
+}
+
+// ValidateTimezone checks that name is an IANA time zone name such as
+// "Europe/Berlin" known to this system. Programs running where zone data may
+// be missing can import time/tzdata.
+func ValidateTimezone(name string) error {
+	if name == "" || name == "Local" {
+		return fmt.Errorf("invalid time zone %q: an IANA name such as Europe/Berlin is required", name)
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return fmt.Errorf("invalid time zone %q: %w", name, err)
+	}
+	return nil
+}
+
+// resolveRange renders Range into Ts and Timezone and validates Timezone
+func (r InsightsQueryRequest) resolveRange() (InsightsQueryRequest, error) {
+	if r.Range != nil {
+		if r.Range.err != nil {
+			return r, r.Range.err
+		}
+		if r.Ts != "" {
+			return r, errors.New("insights query request sets both Ts and Range")
+		}
+		r.Ts = r.Range.ts
+		if r.Timezone == "" && r.Range.location != nil && r.Range.location.String() != "Local" {
+			r.Timezone = r.Range.location.String()
+		}
+		r.Range = nil
+	}
+	if r.Timezone != "" {
+		if err := ValidateTimezone(r.Timezone); err != nil {
+			return r, err
+		}
+	}
+	return r, nil
+}
+
+// StartTime parses StartAt
+func (m InsightsQueryMeta) StartTime() (time.Time, error) {
+	return parseInsightsTime(m.StartAt)
+}
+
+// EndTime parses EndAt
+func (m InsightsQueryMeta) EndTime() (time.Time, error) {
+	return parseInsightsTime(m.EndAt)
+}
+
+// parseInsightsTime parses a timestamp in one of insightsTimeLayouts, in UTC
+// unless it has an offset
+func parseInsightsTime(s string) (time.Time, error) {
+	for _, layout := range insightsTimeLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.UTC); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("cannot parse %q as a timestamp", s)
+}
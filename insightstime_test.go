@@ -0,0 +1,149 @@
+package honeybadgerapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestInsightsTimeRange_String(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		r        *InsightsTimeRange
+		expected string
+	}{
+		{"hours", LastHours(6), "PT6H"},
+		{"days", LastDays(7), "P7D"},
+		{"mixed units", Last(90*time.Minute + 5*time.Second), "PT1H30M5S"},
+		{"whole days", Last(48 * time.Hour), "P2D"},
+		{
+			"between is sent in UTC",
+			Between(time.Date(2024, 1, 1, 9, 0, 0, 0, berlin), time.Date(2024, 1, 1, 17, 30, 0, 0, berlin)),
+			"2024-01-01T08:00:00Z/2024-01-01T16:30:00Z",
+		},
+		{
+			// Clocks go forward on 2024-03-31, so the day is 23 hours long
+			"day across spring DST change",
+			DayOf(time.Date(2024, 3, 31, 15, 0, 0, 0, berlin)),
+			"2024-03-30T23:00:00Z/2024-03-31T22:00:00Z",
+		},
+		{
+			// Clocks go back on 2024-10-27, so the day is 25 hours long
+			"day across autumn DST change",
+			DayOf(time.Date(2024, 10, 27, 1, 0, 0, 0, berlin)),
+			"2024-10-26T22:00:00Z/2024-10-27T23:00:00Z",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.r.err != nil {
+				t.Fatalf("unexpected error: %v", tt.r.err)
+			}
+			if got := tt.r.String(); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestToday(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+
+	r := Today(tokyo)
+	if r.err != nil {
+		t.Fatalf("unexpected error: %v", r.err)
+	}
+	now := time.Now().In(tokyo)
+	if expected := DayOf(now).String(); r.String() != expected {
+		t.Errorf("expected %s, got %s", expected, r.String())
+	}
+	if r.location != tokyo {
+		t.Errorf("expected location Asia/Tokyo, got %v", r.location)
+	}
+	if Today(nil).location != time.UTC {
+		t.Error("expected Today(nil) to use UTC")
+	}
+}
+
+func TestValidateTimezone(t *testing.T) {
+	for _, name := range []string{"UTC", "America/New_York", "Europe/Berlin"} {
+		if err := ValidateTimezone(name); err != nil {
+			t.Errorf("expected %s to be valid, got %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "Local", "Mars/Olympus_Mons", "EST5EDT/../x"} {
+		if err := ValidateTimezone(name); err == nil {
+			t.Errorf("expected %q to be invalid", name)
+		}
+	}
+}
+
+func TestInsightsQuery_Range(t *testing.T) {
+	var received InsightsQueryRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results": [], "meta": {"start_at": "2024-03-30 23:00:00", "end_at": "2024-03-31T22:00:00Z"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient().WithBaseURL(server.URL).WithAuthToken("test-token")
+	ctx := context.Background()
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+
+	day := DayOf(time.Date(2024, 3, 31, 12, 0, 0, 0, berlin))
+	response, err := client.Insights.Query(ctx, 123, InsightsQueryRequest{Query: "fields a", Range: day})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if received.Ts != "2024-03-30T23:00:00Z/2024-03-31T22:00:00Z" {
+		t.Errorf("unexpected ts %q", received.Ts)
+	}
+	if received.Timezone != "Europe/Berlin" {
+		t.Errorf("expected timezone Europe/Berlin, got %q", received.Timezone)
+	}
+
+	start, err := response.Meta.StartTime()
+	if err != nil || !start.Equal(time.Date(2024, 3, 30, 23, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected start time %s (%v)", start, err)
+	}
+	end, err := response.Meta.EndTime()
+	if err != nil || end.Sub(start) != 23*time.Hour {
+		t.Errorf("expected a 23 hour window, got %s (%v)", end.Sub(start), err)
+	}
+
+	// An explicit Timezone wins over the range's location
+	_, err = client.Insights.Query(ctx, 123, InsightsQueryRequest{Query: "fields a", Range: day, Timezone: "UTC"})
+	if err != nil || received.Timezone != "UTC" {
+		t.Errorf("expected timezone UTC, got %q (%v)", received.Timezone, err)
+	}
+
+	invalid := []InsightsQueryRequest{
+		{Query: "fields a", Timezone: "Mars/Olympus_Mons"},
+		{Query: "fields a", Ts: "PT1H", Range: day},
+		{Query: "fields a", Range: LastHours(0)},
+		{Query: "fields a", Range: Between(time.Now(), time.Now().Add(-time.Hour))},
+	}
+	for i, request := range invalid {
+		if _, err := client.Insights.Query(ctx, 123, request); err == nil {
+			t.Errorf("request %d: expected an error", i)
+		}
+	}
+
+	if _, err := (InsightsQueryMeta{StartAt: "yesterday"}).StartTime(); err == nil {
+		t.Error("expected an error parsing an invalid timestamp")
+	}
+}
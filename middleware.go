@@ -0,0 +1,97 @@
+package honeybadgerapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Operation describes a single logical API call as seen by middleware
+type Operation struct {
+	Name       string         // Logical operation name, e.g. "Faults.List"; empty for unannotated requests
+	ProjectID  int            // Project the call is scoped to, or 0
+	ResourceID string         // Primary resource identifier (fault, check-in, site, ...), if any
+	Body       interface{}    // Request body before JSON encoding, or nil
+	Request    *http.Request  // Outgoing HTTP request; middleware may modify it before calling next
+	Response   *http.Response // HTTP response, set once next returns; the body has already been consumed
+	Result     interface{}    // Destination the response body is decoded into, or nil
+	Attempts   int            // Number of HTTP attempts made so far, including retries
+
+	sent bool // Whether Request has been sent and its body consumed
+}
+
+// RoundTrip performs an operation and returns the decoded error, if any
+type RoundTrip func(ctx context.Context, op *Operation) error
+
+// Middleware wraps a RoundTrip to observe or modify operations
+type Middleware func(next RoundTrip) RoundTrip
+
+// Use appends middleware to the client's chain. Middleware added first is the
+// outermost and sees each operation first. Retries and throttling happen
+// inside the chain, so middleware observes one call per logical operation.
+func (c *Client) Use(middleware ...Middleware) *Client {
+	c.middleware = append(c.middleware, middleware...)
+	return c
+}
+
+type operationContextKey struct{}
+
+type requestOperationKey struct{}
+
+// withOperation annotates ctx with the logical operation performed by a service method
+func withOperation(ctx context.Context, name string, projectID int, resourceID interface{}) context.Context {
+	op := Operation{Name: name, ProjectID: projectID}
+	if resourceID != nil {
+		op.ResourceID = fmt.Sprint(resourceID)
+	}
+	return context.WithValue(ctx, operationContextKey{}, op)
+}
+
+// operationFromContext returns the operation annotated on ctx, if any
+func operationFromContext(ctx context.Context) Operation {
+	op, _ := ctx.Value(operationContextKey{}).(Operation)
+	return op
+}
+
+// requestOperation returns the operation attached to req by newRequest
+func requestOperation(req *http.Request) *Operation {
+	if op, ok := req.Context().Value(requestOperationKey{}).(*Operation); ok {
+		return op
+	}
+	return &Operation{}
+}
+
+// roundTrip is the innermost RoundTrip: it sends the request and decodes the response
+func (c *Client) roundTrip(ctx context.Context, op *Operation) error {
+	req := op.Request
+	if op.sent {
+		// The chain is being replayed (e.g. after an auth refresh); restore the body
+		rewound, err := rewindRequest(req)
+		if err != nil {
+			return err
+		}
+		req = rewound
+	}
+	op.sent = true
+
+	resp, attempts, err := c.send(ctx, req)
+	op.Attempts += attempts
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	op.Response = resp
+
+	if resp.StatusCode >= 400 {
+		return WrapError(resp, nil)
+	}
+
+	if op.Result != nil {
+		if err := json.NewDecoder(resp.Body).Decode(op.Result); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,212 @@
+package honeybadgerapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMiddleware_SeesOperation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Request-Tag") != "batch-42" {
+			t.Errorf("expected X-Request-Tag batch-42, got %s", r.Header.Get("X-Request-Tag"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": 7, "body": "Looking into it"}`))
+	}))
+	defer server.Close()
+
+	var seen Operation
+	var status int
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token").
+		Use(func(next RoundTrip) RoundTrip {
+			return func(ctx context.Context, op *Operation) error {
+				op.Request.Header.Set("X-Request-Tag", "batch-42")
+				err := next(ctx, op)
+				seen = *op
+				status = op.Response.StatusCode
+				return err
+			}
+		})
+
+	comment, err := client.Comments.Create(context.Background(), 123, 456, "Looking into it")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if seen.Name != "Comments.Create" {
+		t.Errorf("expected operation name Comments.Create, got %s", seen.Name)
+	}
+	if seen.ProjectID != 123 {
+		t.Errorf("expected project ID 123, got %d", seen.ProjectID)
+	}
+	if seen.ResourceID != "456" {
+		t.Errorf("expected resource ID 456, got %s", seen.ResourceID)
+	}
+	body, ok := seen.Body.(CommentRequest)
+	if !ok {
+		t.Fatalf("expected CommentRequest body, got %T", seen.Body)
+	}
+	if body.Comment.Body != "Looking into it" {
+		t.Errorf("expected comment body 'Looking into it', got %s", body.Comment.Body)
+	}
+	if seen.Result != comment {
+		t.Error("expected Result to point at the decoded comment")
+	}
+	if status != http.StatusCreated {
+		t.Errorf("expected status 201, got %d", status)
+	}
+}
+
+func TestMiddleware_SeesDecodedError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errors": "Fault not found"}`))
+	}))
+	defer server.Close()
+
+	var seenErr error
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token").
+		Use(func(next RoundTrip) RoundTrip {
+			return func(ctx context.Context, op *Operation) error {
+				seenErr = next(ctx, op)
+				return seenErr
+			}
+		})
+
+	_, err := client.Faults.Get(context.Background(), 123, 1)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	var apiErr *APIError
+	if !errors.As(seenErr, &apiErr) {
+		t.Fatalf("expected middleware to see APIError, got %T", seenErr)
+	}
+	if apiErr.Message != "Fault not found" {
+		t.Errorf("expected error message 'Fault not found', got %s", apiErr.Message)
+	}
+}
+
+func TestMiddleware_Order(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next RoundTrip) RoundTrip {
+			return func(ctx context.Context, op *Operation) error {
+				calls = append(calls, name+" before")
+				err := next(ctx, op)
+				calls = append(calls, name+" after")
+				return err
+			}
+		}
+	}
+
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token").
+		Use(trace("outer")).
+		Use(trace("inner"))
+
+	if err := client.Teams.Delete(context.Background(), 1); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	want := []string{"outer before", "inner before", "inner after", "outer after"}
+	if len(calls) != len(want) {
+		t.Fatalf("expected calls %v, got %v", want, calls)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Errorf("expected call %d to be %s, got %s", i, want[i], calls[i])
+		}
+	}
+}
+
+func TestMiddleware_ReplayAfterAuthRefresh(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+
+		username, _, _ := r.BasicAuth()
+		if username != "fresh-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("stale-token").
+		Use(func(next RoundTrip) RoundTrip {
+			return func(ctx context.Context, op *Operation) error {
+				err := next(ctx, op)
+				var apiErr *APIError
+				if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized {
+					op.Request.SetBasicAuth("fresh-token", "")
+					return next(ctx, op)
+				}
+				return err
+			}
+		})
+
+	if err := client.Teams.Update(context.Background(), 1, "Renamed"); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	if len(bodies) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(bodies))
+	}
+	if bodies[0] == "" || bodies[0] != bodies[1] {
+		t.Errorf("expected replayed request to carry the same body, got %q", bodies)
+	}
+}
+
+func TestMiddleware_PagerOperation(t *testing.T) {
+	server := newPaginatedFaultsServer(t, nil)
+	defer server.Close()
+
+	var names []string
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token").
+		Use(func(next RoundTrip) RoundTrip {
+			return func(ctx context.Context, op *Operation) error {
+				if op.ProjectID != 123 {
+					t.Errorf("expected project ID 123, got %d", op.ProjectID)
+				}
+				names = append(names, op.Name)
+				return next(ctx, op)
+			}
+		})
+
+	for _, err := range client.Faults.All(context.Background(), 123, FaultListOptions{}) {
+		if err != nil {
+			t.Fatalf("All() error = %v", err)
+		}
+	}
+
+	if len(names) != 3 {
+		t.Fatalf("expected 3 operations, got %d", len(names))
+	}
+	for _, name := range names {
+		if name != "Faults.List" {
+			t.Errorf("expected operation name Faults.List, got %s", name)
+		}
+	}
+}
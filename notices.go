@@ -0,0 +1,269 @@
+package honeybadgerapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// NoticesReporter reports errors to the Honeybadger Reporting API. It
+// authenticates with the project API key set by WithAPIKey.
+type NoticesReporter struct {
+	client *Client
+}
+
+// maxBacktraceFrames limits the number of stack frames captured for a notice
+const maxBacktraceFrames = 64
+
+// NoticeNotifier identifies the library that sent a notice
+type NoticeNotifier struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// defaultNotifier identifies this module in submitted notices
+var defaultNotifier = NoticeNotifier{
+	Name: "honeybadger-io/api-go",
+	URL:  "https://github.com/honeybadger-io/api-go",
+}
+
+// NoticeCause is an error in the chain behind a reported error
+type NoticeCause struct {
+	Class     string           `json:"class"`
+	Message   string           `json:"message"`
+	Backtrace []BacktraceEntry `json:"backtrace"`
+}
+
+// NoticeError is the error section of a notice payload
+type NoticeError struct {
+	Class       string           `json:"class"`
+	Message     string           `json:"message"`
+	Backtrace   []BacktraceEntry `json:"backtrace"`
+	Causes      []NoticeCause    `json:"causes,omitempty"`
+	Tags        []string         `json:"tags,omitempty"`
+	Fingerprint string           `json:"fingerprint,omitempty"`
+}
+
+// NoticePayload is the body POSTed to the Reporting API for a single error
+type NoticePayload struct {
+	Notifier NoticeNotifier    `json:"notifier"`
+	Error    NoticeError       `json:"error"`
+	Request  *NoticeRequest    `json:"request,omitempty"`
+	Server   NoticeEnvironment `json:"server"`
+}
+
+// NoticeParams adds context to a reported error
+type NoticeParams struct {
+	Class       string                 // Overrides the class derived from the error's type
+	Context     map[string]interface{} // Merged into Request.Context
+	Request     *NoticeRequest         // HTTP request the error occurred in, if any
+	Environment *NoticeEnvironment     // Server details; empty fields default to this host and process
+	Tags        []string               // Normalized with NormalizeTags
+	Fingerprint string                 // Custom grouping key
+	Skip        int                    // Additional stack frames to skip above the caller
+}
+
+// NoticeResponse is the Reporting API's response to a submitted notice
+type NoticeResponse struct {
+	ID string `json:"id"` // UUID of the notice
+}
+
+// NewNotice builds a notice payload for err, capturing the caller's stack
+// trace. Wrapped errors and errors joined with errors.Join are reported as
+// causes.
+func NewNotice(err error, params NoticeParams) *NoticePayload {
+	return buildNotice(err, params, params.Skip+1)
+}
+
+// Notify reports err to Honeybadger, capturing the caller's stack trace, and
+// returns the ID of the created notice.
+//
+// Honeybadger API docs: https://docs.honeybadger.io/api/reporting-exceptions/
+//
+// POST /v1/notices
+func (n *NoticesReporter) Notify(ctx context.Context, err error, params NoticeParams) (*NoticeResponse, error) {
+	if err == nil {
+		return nil, errors.New("cannot report a nil error")
+	}
+	return n.Send(ctx, buildNotice(err, params, params.Skip+1))
+}
+
+// Send submits a notice payload built with NewNotice.
+//
+// POST /v1/notices
+func (n *NoticesReporter) Send(ctx context.Context, notice *NoticePayload) (*NoticeResponse, error) {
+	ctx = withOperation(ctx, "Notices.Send", 0, nil)
+
+	req, err := n.client.newReportingRequest(ctx, "POST", "/notices", notice)
+	if err != nil {
+		return nil, err
+	}
+
+	var response NoticeResponse
+	if err := n.client.do(ctx, req, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// buildNotice builds a notice with a backtrace starting skip frames above its caller
+func buildNotice(err error, params NoticeParams, skip int) *NoticePayload {
+	notice := &NoticePayload{
+		Notifier: defaultNotifier,
+		Request:  params.Request,
+		Server:   noticeServer(params.Environment),
+	}
+	if err == nil {
+		return notice
+	}
+
+	primary := primaryError(err)
+	notice.Error = NoticeError{
+		Class:       params.Class,
+		Message:     err.Error(),
+		Backtrace:   captureBacktrace(skip + 1),
+		Causes:      noticeCauses(err, primary),
+		Fingerprint: params.Fingerprint,
+	}
+	if notice.Error.Class == "" {
+		notice.Error.Class = errorClass(primary)
+		if isWrapper(primary) && len(notice.Error.Causes) > 0 {
+			// Group joined errors by the first error that was joined
+			notice.Error.Class = notice.Error.Causes[0].Class
+		}
+	}
+	if len(params.Tags) > 0 {
+		notice.Error.Tags = NormalizeTags(params.Tags...)
+	}
+
+	if len(params.Context) > 0 {
+		request := NoticeRequest{}
+		if params.Request != nil {
+			request = *params.Request
+		}
+		merged := map[string]interface{}{}
+		for k, v := range request.Context {
+			merged[k] = v
+		}
+		for k, v := range params.Context {
+			merged[k] = v
+		}
+		request.Context = merged
+		notice.Request = &request
+	}
+
+	return notice
+}
+
+// noticeServer fills in server details that were not given
+func noticeServer(env *NoticeEnvironment) NoticeEnvironment {
+	server := NoticeEnvironment{}
+	if env != nil {
+		server = *env
+	}
+	if server.Hostname == "" {
+		server.Hostname, _ = os.Hostname()
+	}
+	if server.ProjectRoot == nil {
+		if wd, err := os.Getwd(); err == nil {
+			server.ProjectRoot = wd
+		}
+	}
+	if server.PID == 0 {
+		server.PID = os.Getpid()
+	}
+	if server.Time == "" {
+		server.Time = time.Now().UTC().Format(time.RFC3339)
+	}
+	return server
+}
+
+// isWrapper reports whether err only wraps other errors without adding a
+// meaningful type, as with fmt.Errorf("...: %w", err) and errors.Join
+func isWrapper(err error) bool {
+	switch fmt.Sprintf("%T", err) {
+	case "*fmt.wrapError", "*fmt.wrapErrors", "*errors.joinError":
+		return true
+	}
+	return false
+}
+
+// primaryError returns the first error in err's chain with a meaningful type,
+// so faults are grouped by that type rather than by a generic wrapper. If
+// there is none, err itself is returned.
+func primaryError(err error) error {
+	for e := err; e != nil; {
+		if !isWrapper(e) {
+			return e
+		}
+		u, ok := e.(interface{ Unwrap() error })
+		if !ok {
+			break
+		}
+		e = u.Unwrap()
+	}
+	return err
+}
+
+// noticeCauses walks err's chain depth first, including every branch of
+// joined errors, and returns each error other than the primary one and
+// generic wrappers. The primary error, if it is not a wrapper, is the first
+// one the walk reaches.
+func noticeCauses(err, primary error) []NoticeCause {
+	var causes []NoticeCause
+	skipPrimary := !isWrapper(primary)
+	var walk func(error)
+	walk = func(e error) {
+		if e == nil {
+			return
+		}
+		if skipPrimary && !isWrapper(e) {
+			skipPrimary = false
+		} else if !isWrapper(e) {
+			causes = append(causes, NoticeCause{Class: errorClass(e), Message: e.Error(), Backtrace: []BacktraceEntry{}})
+		}
+		switch u := e.(type) {
+		case interface{ Unwrap() error }:
+			walk(u.Unwrap())
+		case interface{ Unwrap() []error }:
+			for _, child := range u.Unwrap() {
+				walk(child)
+			}
+		}
+	}
+	walk(err)
+	return causes
+}
+
+// errorClass returns the Go type of err without the pointer prefix, e.g. "os.PathError"
+func errorClass(err error) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", err), "*")
+}
+
+// captureBacktrace returns the current goroutine's stack starting skip frames above its caller
+func captureBacktrace(skip int) []BacktraceEntry {
+	pcs := make([]uintptr, maxBacktraceFrames)
+	n := runtime.Callers(skip+2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	backtrace := []BacktraceEntry{}
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "" {
+			backtrace = append(backtrace, BacktraceEntry{
+				Number: Number(frame.Line),
+				File:   frame.File,
+				Method: frame.Function,
+			})
+		}
+		if !more {
+			break
+		}
+	}
+	return backtrace
+}
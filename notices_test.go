@@ -0,0 +1,160 @@
+package honeybadgerapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestNotify(t *testing.T) {
+	var payload NoticePayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("expected POST request, got %s", r.Method)
+		}
+		if r.URL.Path != "/v1/notices" {
+			t.Errorf("expected path /v1/notices, got %s", r.URL.Path)
+		}
+		if r.Header.Get("X-API-Key") != "project-key" {
+			t.Errorf("expected X-API-Key project-key, got %s", r.Header.Get("X-API-Key"))
+		}
+		if _, _, ok := r.BasicAuth(); ok {
+			t.Error("expected no basic auth on Reporting API requests")
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Fatalf("failed to decode payload: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": "6b1c29d6-2c9d-4d34-bf1c-6f6b0f0b5f11"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient().
+		WithReportingURL(server.URL).
+		WithAPIKey("project-key")
+
+	err := fmt.Errorf("loading config: %w", &fs.PathError{Op: "open", Path: "config.yml", Err: fs.ErrNotExist})
+	response, notifyErr := client.Notices.Notify(context.Background(), err, NoticeParams{
+		Context:     map[string]interface{}{"user_id": 42},
+		Environment: &NoticeEnvironment{EnvironmentName: "production"},
+		Tags:        []string{"config, boot"},
+	})
+	if notifyErr != nil {
+		t.Fatalf("Notify() error = %v", notifyErr)
+	}
+	if response.ID != "6b1c29d6-2c9d-4d34-bf1c-6f6b0f0b5f11" {
+		t.Errorf("expected notice ID, got %s", response.ID)
+	}
+
+	if payload.Error.Class != "fs.PathError" {
+		t.Errorf("expected class fs.PathError, got %s", payload.Error.Class)
+	}
+	if payload.Error.Message != "loading config: open config.yml: file does not exist" {
+		t.Errorf("unexpected message %s", payload.Error.Message)
+	}
+	if len(payload.Error.Causes) != 1 || payload.Error.Causes[0].Class != "errors.errorString" {
+		t.Errorf("expected the wrapped ErrNotExist as the only cause, got %+v", payload.Error.Causes)
+	}
+	if fmt.Sprint(payload.Error.Tags) != "[boot config]" {
+		t.Errorf("expected tags [boot config], got %v", payload.Error.Tags)
+	}
+	if payload.Request == nil || payload.Request.Context["user_id"] != float64(42) {
+		t.Errorf("expected user_id in request context, got %+v", payload.Request)
+	}
+	if payload.Server.EnvironmentName != "production" || payload.Server.PID != os.Getpid() {
+		t.Errorf("unexpected server section %+v", payload.Server)
+	}
+	if payload.Notifier.Name == "" {
+		t.Error("expected notifier name to be set")
+	}
+
+	if len(payload.Error.Backtrace) == 0 {
+		t.Fatal("expected a backtrace")
+	}
+	if top := payload.Error.Backtrace[0]; !strings.HasSuffix(top.Method, "TestNotify") || !strings.HasSuffix(top.File, "notices_test.go") {
+		t.Errorf("expected backtrace to start at the caller, got %s in %s", top.Method, top.File)
+	}
+}
+
+func TestNotify_MissingAPIKey(t *testing.T) {
+	client := NewClient().WithAuthToken("test-token")
+
+	_, err := client.Notices.Notify(context.Background(), errors.New("boom"), NoticeParams{})
+	if !errors.Is(err, ErrMissingAPIKey) {
+		t.Errorf("expected ErrMissingAPIKey, got %v", err)
+	}
+}
+
+func TestNotify_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error": "Invalid API key"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient().
+		WithReportingURL(server.URL).
+		WithAPIKey("bad-key")
+
+	_, err := client.Notices.Notify(context.Background(), errors.New("boom"), NoticeParams{})
+	if !errors.Is(err, ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got %v", err)
+	}
+}
+
+type timeoutError struct{ op string }
+
+func (e timeoutError) Error() string { return e.op + " timed out" }
+
+func TestNewNotice_JoinedErrors(t *testing.T) {
+	err := errors.Join(
+		timeoutError{op: "dial"},
+		fmt.Errorf("cleanup: %w", errors.New("close failed")),
+	)
+
+	notice := NewNotice(err, NoticeParams{Fingerprint: "shutdown"})
+
+	if notice.Error.Class != "honeybadgerapi.timeoutError" {
+		t.Errorf("expected class of the first joined error, got %s", notice.Error.Class)
+	}
+
+	var classes []string
+	for _, cause := range notice.Error.Causes {
+		classes = append(classes, cause.Class)
+	}
+	if fmt.Sprint(classes) != "[honeybadgerapi.timeoutError errors.errorString]" {
+		t.Errorf("expected both joined errors as causes, got %v", classes)
+	}
+	if notice.Error.Fingerprint != "shutdown" {
+		t.Errorf("expected fingerprint shutdown, got %s", notice.Error.Fingerprint)
+	}
+	if !strings.HasSuffix(notice.Error.Backtrace[0].Method, "TestNewNotice_JoinedErrors") {
+		t.Errorf("expected backtrace to start at the caller, got %s", notice.Error.Backtrace[0].Method)
+	}
+}
+
+func TestNewNotice_Skip(t *testing.T) {
+	report := func() *NoticePayload {
+		return NewNotice(errors.New("boom"), NoticeParams{Skip: 1, Class: "Boom"})
+	}
+	notice := report()
+
+	if notice.Error.Class != "Boom" {
+		t.Errorf("expected class override Boom, got %s", notice.Error.Class)
+	}
+	if !strings.HasSuffix(notice.Error.Backtrace[0].Method, "TestNewNotice_Skip") {
+		t.Errorf("expected the helper frame to be skipped, got %s", notice.Error.Backtrace[0].Method)
+	}
+}
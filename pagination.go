@@ -0,0 +1,138 @@
+package honeybadgerapi
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/url"
+	"strings"
+)
+
+// Pager fetches the pages of a paginated list lazily by following
+// PaginationLinks.Next. A Pager is not safe for concurrent use.
+type Pager[T any] struct {
+	client   *Client
+	op       Operation
+	first    func(ctx context.Context) (*ListResponse[T], error)
+	next     string
+	started  bool
+	done     bool
+	maxItems int
+	count    int
+}
+
+// newPager returns a pager that calls first for the initial page and annotates
+// requests for subsequent pages with op
+func newPager[T any](client *Client, op Operation, first func(ctx context.Context) (*ListResponse[T], error)) *Pager[T] {
+	return &Pager[T]{client: client, op: op, first: first}
+}
+
+// WithMaxItems caps the total number of items returned by the pager.
+// A value of zero or less means no limit.
+func (p *Pager[T]) WithMaxItems(n int) *Pager[T] {
+	p.maxItems = n
+	return p
+}
+
+// More reports whether there may be more pages to fetch
+func (p *Pager[T]) More() bool {
+	return !p.done
+}
+
+// NextPage fetches the next page of results. It returns an empty slice once
+// all pages have been consumed.
+func (p *Pager[T]) NextPage(ctx context.Context) ([]T, error) {
+	if p.done {
+		return nil, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var page *ListResponse[T]
+	var err error
+	if !p.started {
+		page, err = p.first(ctx)
+	} else {
+		page, err = p.fetch(ctx, p.next)
+	}
+	if err != nil {
+		return nil, err
+	}
+	p.started = true
+
+	results := page.Results
+	if p.maxItems > 0 && p.count+len(results) >= p.maxItems {
+		results = results[:p.maxItems-p.count]
+		p.done = true
+	}
+	p.count += len(results)
+
+	if page.Links.Next == "" || page.Links.Next == page.Links.Self || len(page.Results) == 0 {
+		p.done = true
+	}
+	p.next = page.Links.Next
+
+	return results, nil
+}
+
+// All returns an iterator over every item across all pages. Iteration stops
+// after the first error, which is yielded with the zero value of T.
+func (p *Pager[T]) All(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for p.More() {
+			page, err := p.NextPage(ctx)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range page {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// fetch requests the page at the given pagination link
+func (p *Pager[T]) fetch(ctx context.Context, link string) (*ListResponse[T], error) {
+	path, err := pathFromLink(link)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = context.WithValue(ctx, operationContextKey{}, p.op)
+	req, err := p.client.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var response ListResponse[T]
+	if err := p.client.do(ctx, req, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// pathFromLink converts a pagination link into a path relative to the /v2 prefix.
+// Only the path and query are used, so requests always go to the client's base URL.
+func pathFromLink(link string) (string, error) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return "", fmt.Errorf("invalid pagination link %q: %w", link, err)
+	}
+
+	path, ok := strings.CutPrefix(u.EscapedPath(), "/v2")
+	if !ok || (path != "" && path[0] != '/') {
+		return "", fmt.Errorf("invalid pagination link %q: expected a /v2 path", link)
+	}
+
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+	return path, nil
+}
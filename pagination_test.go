@@ -0,0 +1,265 @@
+package honeybadgerapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+// newPaginatedFaultsServer serves three pages of two faults each for project 123
+func newPaginatedFaultsServer(t *testing.T, requests *[]string) *httptest.Server {
+	t.Helper()
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests != nil {
+			*requests = append(*requests, r.URL.RequestURI())
+		}
+		if r.URL.Path != "/v2/projects/123/faults" {
+			t.Errorf("expected path /v2/projects/123/faults, got %s", r.URL.Path)
+		}
+
+		page := 1
+		if p := r.URL.Query().Get("page"); p != "" {
+			page, _ = strconv.Atoi(p)
+		}
+
+		next := ""
+		if page < 3 {
+			next = fmt.Sprintf(`%s/v2/projects/123/faults?page=%d&q=NoMethodError`, server.URL, page+1)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintf(w, `{
+			"results": [{"id": %d, "project_id": 123}, {"id": %d, "project_id": 123}],
+			"links": {"self": "%s/v2/projects/123/faults?page=%d", "next": "%s"}
+		}`, page*2-1, page*2, server.URL, page, next)
+	}))
+	return server
+}
+
+func TestPager_All(t *testing.T) {
+	var requests []string
+	server := newPaginatedFaultsServer(t, &requests)
+	defer server.Close()
+
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token")
+
+	var ids []int
+	for fault, err := range client.Faults.All(context.Background(), 123, FaultListOptions{Q: "NoMethodError"}) {
+		if err != nil {
+			t.Fatalf("All() error = %v", err)
+		}
+		ids = append(ids, fault.ID)
+	}
+
+	if len(ids) != 6 {
+		t.Fatalf("expected 6 faults, got %d", len(ids))
+	}
+	for i, id := range ids {
+		if id != i+1 {
+			t.Errorf("expected fault ID %d at position %d, got %d", i+1, i, id)
+		}
+	}
+
+	if len(requests) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(requests))
+	}
+	if requests[1] != "/v2/projects/123/faults?page=2&q=NoMethodError" {
+		t.Errorf("expected second request to follow next link, got %s", requests[1])
+	}
+}
+
+func TestPager_MaxItems(t *testing.T) {
+	var requests []string
+	server := newPaginatedFaultsServer(t, &requests)
+	defer server.Close()
+
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token")
+
+	pager := client.Faults.ListPager(123, FaultListOptions{}).WithMaxItems(3)
+
+	var ids []int
+	for fault, err := range pager.All(context.Background()) {
+		if err != nil {
+			t.Fatalf("All() error = %v", err)
+		}
+		ids = append(ids, fault.ID)
+	}
+
+	if len(ids) != 3 {
+		t.Errorf("expected 3 faults, got %d", len(ids))
+	}
+	if len(requests) != 2 {
+		t.Errorf("expected 2 requests, got %d", len(requests))
+	}
+	if pager.More() {
+		t.Error("expected pager to be exhausted")
+	}
+}
+
+func TestPager_NextPage(t *testing.T) {
+	server := newPaginatedFaultsServer(t, nil)
+	defer server.Close()
+
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token")
+
+	pager := client.Faults.ListPager(123, FaultListOptions{})
+
+	pages := 0
+	for pager.More() {
+		page, err := pager.NextPage(context.Background())
+		if err != nil {
+			t.Fatalf("NextPage() error = %v", err)
+		}
+		if len(page) != 2 {
+			t.Errorf("expected 2 faults on page %d, got %d", pages+1, len(page))
+		}
+		pages++
+	}
+
+	if pages != 3 {
+		t.Errorf("expected 3 pages, got %d", pages)
+	}
+
+	page, err := pager.NextPage(context.Background())
+	if err != nil || page != nil {
+		t.Errorf("expected nil page after exhaustion, got %v, %v", page, err)
+	}
+}
+
+func TestPager_StopsOnBreak(t *testing.T) {
+	var requests []string
+	server := newPaginatedFaultsServer(t, &requests)
+	defer server.Close()
+
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token")
+
+	for fault, err := range client.Faults.All(context.Background(), 123, FaultListOptions{}) {
+		if err != nil {
+			t.Fatalf("All() error = %v", err)
+		}
+		if fault.ID == 3 {
+			break
+		}
+	}
+
+	if len(requests) != 2 {
+		t.Errorf("expected 2 requests, got %d", len(requests))
+	}
+}
+
+func TestPager_ContextCanceled(t *testing.T) {
+	server := newPaginatedFaultsServer(t, nil)
+	defer server.Close()
+
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var count int
+	var lastErr error
+	for _, err := range client.Faults.All(ctx, 123, FaultListOptions{}) {
+		if err != nil {
+			lastErr = err
+			break
+		}
+		count++
+		if count == 2 {
+			cancel()
+		}
+	}
+
+	if count != 2 {
+		t.Errorf("expected 2 faults before cancellation, got %d", count)
+	}
+	if !errors.Is(lastErr, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", lastErr)
+	}
+}
+
+func TestPager_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"errors": "Access denied"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token")
+
+	var errs []error
+	for _, err := range client.Projects.All(context.Background()) {
+		errs = append(errs, err)
+	}
+
+	if len(errs) != 1 {
+		t.Fatalf("expected a single error, got %d values", len(errs))
+	}
+	apiErr, ok := errs[0].(*APIError)
+	if !ok {
+		t.Fatalf("expected APIError, got %T", errs[0])
+	}
+	if apiErr.StatusCode != 403 {
+		t.Errorf("expected status code 403, got %d", apiErr.StatusCode)
+	}
+}
+
+func TestPathFromLink(t *testing.T) {
+	tests := []struct {
+		name    string
+		link    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "absolute link",
+			link: "https://app.honeybadger.io/v2/projects/123/faults?page=2",
+			want: "/projects/123/faults?page=2",
+		},
+		{
+			name: "relative link",
+			link: "/v2/projects?account_id=abc&page=3",
+			want: "/projects?account_id=abc&page=3",
+		},
+		{
+			name:    "missing v2 prefix",
+			link:    "https://app.honeybadger.io/projects/123/faults",
+			wantErr: true,
+		},
+		{
+			name:    "different version prefix",
+			link:    "/v20/projects",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := pathFromLink(tt.link)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("pathFromLink() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("pathFromLink() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package honeybadgerapi
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/url"
 	"time"
 )
@@ -21,6 +22,8 @@ type ProjectsResponse = ListResponse[Project]
 //
 // GET /projects
 func (p *ProjectsService) ListAll(ctx context.Context) (*ProjectsResponse, error) {
+	ctx = withOperation(ctx, "Projects.ListAll", 0, nil)
+
 	req, err := p.client.newRequest(ctx, "GET", "/projects", nil)
 	if err != nil {
 		return nil, err
@@ -34,12 +37,25 @@ func (p *ProjectsService) ListAll(ctx context.Context) (*ProjectsResponse, error
 	return &response, nil
 }
 
+// ListAllPager returns a pager over all projects accessible by the authenticated user.
+func (p *ProjectsService) ListAllPager() *Pager[Project] {
+	return newPager(p.client, Operation{Name: "Projects.ListAll"}, p.ListAll)
+}
+
+// All returns an iterator over all projects accessible by the authenticated user,
+// fetching additional pages as needed.
+func (p *ProjectsService) All(ctx context.Context) iter.Seq2[Project, error] {
+	return p.ListAllPager().All(ctx)
+}
+
 // ListByAccountID returns all projects filtered by account_id.
 //
 // Honeybadger API docs: https://docs.honeybadger.io/api/projects/#get-a-project-list-or-project-details
 //
 // GET /projects?account_id={accountID}
 func (p *ProjectsService) ListByAccountID(ctx context.Context, accountID string) (*ProjectsResponse, error) {
+	ctx = withOperation(ctx, "Projects.ListByAccountID", 0, accountID)
+
 	path := fmt.Sprintf("/projects?account_id=%s", accountID)
 
 	req, err := p.client.newRequest(ctx, "GET", path, nil)
@@ -55,12 +71,27 @@ func (p *ProjectsService) ListByAccountID(ctx context.Context, accountID string)
 	return &response, nil
 }
 
+// ListByAccountIDPager returns a pager over the projects for an account.
+func (p *ProjectsService) ListByAccountIDPager(accountID string) *Pager[Project] {
+	return newPager(p.client, Operation{Name: "Projects.ListByAccountID", ResourceID: accountID}, func(ctx context.Context) (*ProjectsResponse, error) {
+		return p.ListByAccountID(ctx, accountID)
+	})
+}
+
+// AllByAccountID returns an iterator over all projects for an account,
+// fetching additional pages as needed.
+func (p *ProjectsService) AllByAccountID(ctx context.Context, accountID string) iter.Seq2[Project, error] {
+	return p.ListByAccountIDPager(accountID).All(ctx)
+}
+
 // Get returns a single project by ID with full project details.
 //
 // Honeybadger API docs: https://docs.honeybadger.io/api/projects/#get-a-project-list-or-project-details
 //
 // GET /projects/{id}
 func (p *ProjectsService) Get(ctx context.Context, id int) (*Project, error) {
+	ctx = withOperation(ctx, "Projects.Get", id, nil)
+
 	path := fmt.Sprintf("/projects/%d", id)
 	req, err := p.client.newRequest(ctx, "GET", path, nil)
 	if err != nil {
@@ -92,6 +123,8 @@ type ProjectRequest struct {
 //
 // POST /projects?account_id={accountID}
 func (p *ProjectsService) Create(ctx context.Context, accountID string, req ProjectRequest) (*Project, error) {
+	ctx = withOperation(ctx, "Projects.Create", 0, accountID)
+
 	body := map[string]interface{}{
 		"project": req,
 	}
@@ -122,6 +155,8 @@ type UpdateResult struct {
 //
 // PUT /projects/{id}
 func (p *ProjectsService) Update(ctx context.Context, id int, req ProjectRequest) (*UpdateResult, error) {
+	ctx = withOperation(ctx, "Projects.Update", id, nil)
+
 	body := map[string]interface{}{
 		"project": req,
 	}
@@ -155,6 +190,8 @@ type DeleteResult struct {
 //
 // DELETE /projects/{id}
 func (p *ProjectsService) Delete(ctx context.Context, id int) (*DeleteResult, error) {
+	ctx = withOperation(ctx, "Projects.Delete", id, nil)
+
 	path := fmt.Sprintf("/projects/%d", id)
 	req, err := p.client.newRequest(ctx, "DELETE", path, nil)
 	if err != nil {
@@ -189,6 +226,8 @@ type ProjectGetOccurrenceCountsResponse []ProjectOccurrenceCount
 //
 // GET /projects/occurrences
 func (p *ProjectsService) GetAllOccurrenceCounts(ctx context.Context, options ProjectGetOccurrenceCountsOptions) (ProjectGetAllOccurrenceCountsResponse, error) {
+	ctx = withOperation(ctx, "Projects.GetAllOccurrenceCounts", 0, nil)
+
 	path := "/projects/occurrences"
 
 	// Build query parameters using url.Values
@@ -227,6 +266,8 @@ type ProjectGetAllOccurrenceCountsResponse map[string][]ProjectOccurrenceCount
 //
 // GET /projects/{projectID}/occurrences
 func (p *ProjectsService) GetOccurrenceCounts(ctx context.Context, projectID int, options ProjectGetOccurrenceCountsOptions) (ProjectGetOccurrenceCountsResponse, error) {
+	ctx = withOperation(ctx, "Projects.GetOccurrenceCounts", projectID, nil)
+
 	path := fmt.Sprintf("/projects/%d/occurrences", projectID)
 
 	// Build query parameters using url.Values
@@ -273,6 +314,8 @@ type ProjectIntegration struct {
 //
 // GET /projects/{projectID}/integrations
 func (p *ProjectsService) GetIntegrations(ctx context.Context, projectID int) ([]ProjectIntegration, error) {
+	ctx = withOperation(ctx, "Projects.GetIntegrations", projectID, nil)
+
 	path := fmt.Sprintf("/projects/%d/integrations", projectID)
 	req, err := p.client.newRequest(ctx, "GET", path, nil)
 	if err != nil {
@@ -310,6 +353,8 @@ type ProjectGetReportOptions struct {
 //
 // GET /projects/{projectID}/reports/{reportType}
 func (p *ProjectsService) GetReport(ctx context.Context, projectID int, reportType ProjectReportType, options ProjectGetReportOptions) ([][]interface{}, error) {
+	ctx = withOperation(ctx, "Projects.GetReport", projectID, nil)
+
 	path := fmt.Sprintf("/projects/%d/reports/%s", projectID, reportType)
 
 	// Build query parameters using url.Values
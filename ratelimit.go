@@ -0,0 +1,167 @@
+package honeybadgerapi
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimit represents the rate limit status reported by the API
+type RateLimit struct {
+	Limit     int       // Requests allowed in the current window
+	Remaining int       // Requests remaining in the current window
+	Reset     time.Time // When the current window resets
+}
+
+// parseRateLimit extracts rate limit information from response headers.
+// It returns false if the response carries no rate limit headers.
+func parseRateLimit(h http.Header) (RateLimit, bool) {
+	var rl RateLimit
+	found := false
+
+	if v, err := strconv.Atoi(h.Get("X-RateLimit-Limit")); err == nil {
+		rl.Limit = v
+		found = true
+	}
+	if v, err := strconv.Atoi(h.Get("X-RateLimit-Remaining")); err == nil {
+		rl.Remaining = v
+		found = true
+	}
+	if v, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		rl.Reset = time.Unix(v, 0)
+		found = true
+	}
+
+	return rl, found
+}
+
+// RateLimit returns the rate limit status from the most recent response that
+// reported one. The zero value is returned if no response has been seen yet.
+func (c *Client) RateLimit() RateLimit {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rateLimit
+}
+
+// recordRateLimit stores the rate limit status reported by a response
+func (c *Client) recordRateLimit(resp *http.Response) {
+	rl, ok := parseRateLimit(resp.Header)
+	if !ok {
+		return
+	}
+
+	c.mu.Lock()
+	c.rateLimit = rl
+	c.mu.Unlock()
+}
+
+// WithRateLimit enables client-side throttling with a token bucket that allows
+// requestsPerSecond on average and bursts of up to burst requests. Requests
+// block until a token is available or the context is done. While throttling is
+// enabled the client also waits for the server's window to reset once the
+// reported remaining request count reaches zero.
+func (c *Client) WithRateLimit(requestsPerSecond float64, burst int) *Client {
+	if requestsPerSecond <= 0 {
+		c.limiter = nil
+		return c
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	c.limiter = newTokenBucket(requestsPerSecond, burst)
+	return c
+}
+
+// throttle blocks until the client may send another request
+func (c *Client) throttle(ctx context.Context) error {
+	if c.limiter == nil {
+		return nil
+	}
+
+	rl := c.RateLimit()
+	if rl.Limit > 0 && rl.Remaining <= 0 {
+		if wait := time.Until(rl.Reset); wait > 0 {
+			if err := sleep(ctx, wait); err != nil {
+				return err
+			}
+		}
+	}
+
+	return c.limiter.wait(ctx)
+}
+
+// tokenBucket is a simple token bucket rate limiter safe for concurrent use
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64 // Tokens added per second
+	burst  float64 // Maximum number of tokens
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token and returns how long the caller must wait before using it
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel returns a reserved token to the bucket
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens++
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+}
+
+// wait blocks until a token is available or the context is done
+func (b *tokenBucket) wait(ctx context.Context) error {
+	wait := b.reserve(time.Now())
+	if wait <= 0 {
+		return nil
+	}
+
+	if err := sleep(ctx, wait); err != nil {
+		b.cancel()
+		return err
+	}
+	return nil
+}
+
+// sleep pauses for the given duration or until the context is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,133 @@
+package honeybadgerapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	h := http.Header{}
+	if _, ok := parseRateLimit(h); ok {
+		t.Error("expected no rate limit for empty headers")
+	}
+
+	h.Set("X-RateLimit-Limit", "360")
+	h.Set("X-RateLimit-Remaining", "359")
+	h.Set("X-RateLimit-Reset", "1704067200")
+
+	rl, ok := parseRateLimit(h)
+	if !ok {
+		t.Fatal("expected rate limit to be parsed")
+	}
+	if rl.Limit != 360 {
+		t.Errorf("expected limit 360, got %d", rl.Limit)
+	}
+	if rl.Remaining != 359 {
+		t.Errorf("expected remaining 359, got %d", rl.Remaining)
+	}
+	if !rl.Reset.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected reset 2024-01-01T00:00:00Z, got %v", rl.Reset)
+	}
+}
+
+func TestClient_RateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "360")
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		w.Header().Set("X-RateLimit-Reset", "1704067200")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"results": []}`))
+	}))
+	defer server.Close()
+
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token")
+
+	if rl := client.RateLimit(); rl != (RateLimit{}) {
+		t.Errorf("expected zero rate limit before any request, got %+v", rl)
+	}
+
+	if _, err := client.Faults.List(context.Background(), 123, FaultListOptions{}); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	rl := client.RateLimit()
+	if rl.Limit != 360 || rl.Remaining != 42 {
+		t.Errorf("expected limit 360 and remaining 42, got %+v", rl)
+	}
+}
+
+func TestClient_WithRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token").
+		WithRateLimit(20, 2)
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := client.Teams.Delete(context.Background(), i); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+	}
+
+	// Two requests use the burst, the remaining two wait 50ms each
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("expected requests to be throttled, took %v", elapsed)
+	}
+}
+
+func TestClient_WithRateLimit_ContextCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token").
+		WithRateLimit(0.1, 1)
+
+	if err := client.Teams.Delete(context.Background(), 1); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := client.Teams.Delete(ctx, 2)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestTokenBucket_Reserve(t *testing.T) {
+	now := time.Now()
+	b := newTokenBucket(10, 2)
+	b.last = now
+
+	if wait := b.reserve(now); wait != 0 {
+		t.Errorf("expected first token immediately, got %v", wait)
+	}
+	if wait := b.reserve(now); wait != 0 {
+		t.Errorf("expected second token immediately, got %v", wait)
+	}
+	if wait := b.reserve(now); wait != 100*time.Millisecond {
+		t.Errorf("expected to wait 100ms for third token, got %v", wait)
+	}
+
+	b.cancel()
+	if wait := b.reserve(now.Add(100 * time.Millisecond)); wait != 0 {
+		t.Errorf("expected token after refill, got %v", wait)
+	}
+}
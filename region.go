@@ -0,0 +1,132 @@
+package honeybadgerapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Region identifies a Honeybadger data residency region
+type Region string
+
+const (
+	RegionUS Region = "us"
+	RegionEU Region = "eu"
+)
+
+// regionHosts holds the API and reporting endpoints for each region
+var regionHosts = map[Region]struct{ api, reporting string }{
+	RegionUS: {"https://api.honeybadger.io", "https://api.honeybadger.io"},
+	RegionEU: {"https://eu-api.honeybadger.io", "https://eu-api.honeybadger.io"},
+}
+
+// ParseRegion parses a region name such as "us" or "EU"
+func ParseRegion(s string) (Region, error) {
+	r := Region(strings.ToLower(strings.TrimSpace(s)))
+	if _, ok := regionHosts[r]; !ok {
+		return "", fmt.Errorf("unknown region %q", s)
+	}
+	return r, nil
+}
+
+// APIURL returns the Data API base URL for the region
+func (r Region) APIURL() string {
+	return regionHosts[r].api
+}
+
+// ReportingURL returns the Reporting API base URL for the region
+func (r Region) ReportingURL() string {
+	return regionHosts[r].reporting
+}
+
+// WithRegion points the client at the Data API and Reporting API hosts for a region
+func (c *Client) WithRegion(region Region) *Client {
+	c.region = region
+	c.baseURL = region.APIURL()
+	c.reportingURL = region.ReportingURL()
+	return c
+}
+
+// WithReportingURL sets the base URL for Reporting API calls such as notices, events and check-in pings
+func (c *Client) WithReportingURL(reportingURL string) *Client {
+	c.reportingURL = reportingURL
+	return c
+}
+
+// Region returns the region the client was configured for
+func (c *Client) Region() Region {
+	return c.region
+}
+
+// RegionalProject is a project tagged with the region it was listed from
+type RegionalProject struct {
+	Project
+	Region Region `json:"region"`
+}
+
+// RegionError reports a failure in one region of a multi-region call
+type RegionError struct {
+	Region Region
+	Err    error
+}
+
+func (e *RegionError) Error() string {
+	return fmt.Sprintf("region %s: %v", e.Region, e.Err)
+}
+
+func (e *RegionError) Unwrap() error {
+	return e.Err
+}
+
+// MultiRegion fans calls out to one client per region. Personal auth tokens
+// are issued per region, so each client carries its own token.
+type MultiRegion struct {
+	clients []*Client
+}
+
+// NewMultiRegion groups clients configured with WithRegion
+func NewMultiRegion(clients ...*Client) *MultiRegion {
+	return &MultiRegion{clients: clients}
+}
+
+// Client returns the client for a region, or nil if there is none
+func (m *MultiRegion) Client(region Region) *Client {
+	for _, c := range m.clients {
+		if c.region == region {
+			return c
+		}
+	}
+	return nil
+}
+
+// ListProjects lists all projects in every region concurrently. Results are
+// ordered by client, then as returned by the API. If some regions fail, the
+// projects from the others are returned along with the joined *RegionError values.
+func (m *MultiRegion) ListProjects(ctx context.Context) ([]RegionalProject, error) {
+	results := make([][]RegionalProject, len(m.clients))
+	errs := make([]error, len(m.clients))
+
+	var wg sync.WaitGroup
+	for i, c := range m.clients {
+		wg.Add(1)
+		go func(i int, c *Client) {
+			defer wg.Done()
+			for project, err := range c.Projects.All(ctx) {
+				if err != nil {
+					errs[i] = &RegionError{Region: c.region, Err: err}
+					return
+				}
+				results[i] = append(results[i], RegionalProject{Project: project, Region: c.region})
+			}
+		}(i, c)
+	}
+	wg.Wait()
+
+	var projects []RegionalProject
+	for _, r := range results {
+		projects = append(projects, r...)
+	}
+	return projects, errors.Join(errs...)
+}
@@ -0,0 +1,131 @@
+package honeybadgerapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseRegion(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Region
+		wantErr  bool
+	}{
+		{"us", RegionUS, false},
+		{"EU", RegionEU, false},
+		{" eu ", RegionEU, false},
+		{"apac", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseRegion(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseRegion(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+		}
+		if got != tt.expected {
+			t.Errorf("ParseRegion(%q): expected %q, got %q", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestWithRegion(t *testing.T) {
+	client := NewClient()
+	if client.Region() != RegionUS {
+		t.Errorf("expected default region us, got %s", client.Region())
+	}
+
+	client.WithRegion(RegionEU)
+	if client.baseURL != "https://eu-api.honeybadger.io" {
+		t.Errorf("expected EU API URL, got %s", client.baseURL)
+	}
+	if client.reportingURL != "https://eu-api.honeybadger.io" {
+		t.Errorf("expected EU reporting URL, got %s", client.reportingURL)
+	}
+	if client.Region() != RegionEU {
+		t.Errorf("expected region eu, got %s", client.Region())
+	}
+}
+
+func TestConfig_Region(t *testing.T) {
+	clearConfigEnv(t)
+	t.Setenv(EnvRegion, "eu")
+
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv() error = %v", err)
+	}
+	client, err := cfg.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if client.baseURL != RegionEU.APIURL() {
+		t.Errorf("expected EU API URL, got %s", client.baseURL)
+	}
+
+	t.Setenv(EnvRegion, "mars")
+	if _, err := ConfigFromEnv(); err == nil {
+		t.Error("expected error for unknown region, got nil")
+	}
+}
+
+func newRegionServer(t *testing.T, body string, status int) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestMultiRegion_ListProjects(t *testing.T) {
+	us := newRegionServer(t, `{"results": [{"id": 1, "name": "US Shop"}], "links": {}}`, http.StatusOK)
+	eu := newRegionServer(t, `{"results": [{"id": 2, "name": "EU Shop"}, {"id": 3, "name": "EU Blog"}], "links": {}}`, http.StatusOK)
+
+	multi := NewMultiRegion(
+		NewClient().WithRegion(RegionUS).WithBaseURL(us.URL).WithAuthToken("us-token"),
+		NewClient().WithRegion(RegionEU).WithBaseURL(eu.URL).WithAuthToken("eu-token"),
+	)
+
+	projects, err := multi.ListProjects(context.Background())
+	if err != nil {
+		t.Fatalf("ListProjects() error = %v", err)
+	}
+	if len(projects) != 3 {
+		t.Fatalf("expected 3 projects, got %d", len(projects))
+	}
+	if projects[0].Region != RegionUS || projects[0].Name != "US Shop" {
+		t.Errorf("expected US Shop from us, got %s from %s", projects[0].Name, projects[0].Region)
+	}
+	if projects[2].Region != RegionEU || projects[2].ID != 3 {
+		t.Errorf("expected project 3 from eu, got %d from %s", projects[2].ID, projects[2].Region)
+	}
+	if multi.Client(RegionEU) == nil {
+		t.Error("expected a client for eu")
+	}
+}
+
+func TestMultiRegion_PartialFailure(t *testing.T) {
+	us := newRegionServer(t, `{"results": [{"id": 1, "name": "US Shop"}], "links": {}}`, http.StatusOK)
+	eu := newRegionServer(t, `{"errors": "Unauthorized"}`, http.StatusUnauthorized)
+
+	multi := NewMultiRegion(
+		NewClient().WithRegion(RegionUS).WithBaseURL(us.URL).WithAuthToken("us-token"),
+		NewClient().WithRegion(RegionEU).WithBaseURL(eu.URL).WithAuthToken("bad-token"),
+	)
+
+	projects, err := multi.ListProjects(context.Background())
+	if len(projects) != 1 {
+		t.Errorf("expected 1 project from us, got %d", len(projects))
+	}
+
+	var regionErr *RegionError
+	if !errors.As(err, &regionErr) || regionErr.Region != RegionEU {
+		t.Fatalf("expected RegionError for eu, got %v", err)
+	}
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+}
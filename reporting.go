@@ -0,0 +1,51 @@
+package honeybadgerapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ErrMissingAPIKey is returned by Reporting API calls when no project API key is set
+var ErrMissingAPIKey = errors.New("project API key is required for the Reporting API; see WithAPIKey")
+
+// WithAPIKey sets the project API key used by Reporting API calls such as
+// notices, events, check-in pings and deploy notifications. It is separate
+// from the personal auth token used by the Data API.
+func (c *Client) WithAPIKey(apiKey string) *Client {
+	c.apiKey = apiKey
+	return c
+}
+
+// newReportingRequest creates a Reporting API request authenticated with the project API key
+func (c *Client) newReportingRequest(ctx context.Context, method, path string, body interface{}) (*http.Request, error) {
+	if c.apiKey == "" {
+		return nil, ErrMissingAPIKey
+	}
+
+	// Reporting API paths are under /v1
+	req, err := c.buildRequest(ctx, method, fmt.Sprintf("%s/v1%s", c.reportingURL, path), body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-API-Key", c.apiKey)
+
+	return req, nil
+}
+
+// newReportingRawRequest creates a Reporting API request with an already encoded body
+func (c *Client) newReportingRawRequest(ctx context.Context, method, path, contentType string, data io.Reader, body interface{}) (*http.Request, error) {
+	if c.apiKey == "" {
+		return nil, ErrMissingAPIKey
+	}
+
+	req, err := c.buildRawRequest(ctx, method, fmt.Sprintf("%s/v1%s", c.reportingURL, path), contentType, data, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-API-Key", c.apiKey)
+
+	return req, nil
+}
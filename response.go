@@ -0,0 +1,64 @@
+package honeybadgerapi
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Response holds metadata about the HTTP exchange behind a single API call
+type Response struct {
+	Operation  string        // Logical operation name, e.g. "Faults.List"
+	StatusCode int           // HTTP status code, or 0 if no response was received
+	Header     http.Header   // Response headers
+	RequestID  string        // Server-assigned request ID from the X-Request-Id header
+	Latency    time.Duration // Total time spent on the call, including retries and throttling
+	Attempts   int           // Number of HTTP attempts made, including retries
+	RateLimit  RateLimit     // Rate limit status reported with the response
+}
+
+type responseContextKey struct{}
+
+// WithResponse returns a context that records metadata about calls made with it
+// into resp. When several calls share the context, resp describes the last one.
+//
+//	var meta hbapi.Response
+//	faults, err := client.Faults.List(hbapi.WithResponse(ctx, &meta), projectID, opts)
+//	log.Printf("request %s took %s", meta.RequestID, meta.Latency)
+func WithResponse(ctx context.Context, resp *Response) context.Context {
+	return context.WithValue(ctx, responseContextKey{}, resp)
+}
+
+// WithResponseCallback sets a function that is called with metadata after every API call
+func (c *Client) WithResponseCallback(fn func(*Response)) *Client {
+	c.responseCallback = fn
+	return c
+}
+
+// recordResponse reports metadata about a completed operation to the context
+// out-parameter and the response callback, if either is set
+func (c *Client) recordResponse(ctx context.Context, op *Operation, latency time.Duration) {
+	target, _ := ctx.Value(responseContextKey{}).(*Response)
+	if target == nil && c.responseCallback == nil {
+		return
+	}
+
+	resp := &Response{
+		Operation: op.Name,
+		Latency:   latency,
+		Attempts:  op.Attempts,
+	}
+	if op.Response != nil {
+		resp.StatusCode = op.Response.StatusCode
+		resp.Header = op.Response.Header
+		resp.RequestID = op.Response.Header.Get("X-Request-Id")
+		resp.RateLimit, _ = parseRateLimit(op.Response.Header)
+	}
+
+	if target != nil {
+		*target = *resp
+	}
+	if c.responseCallback != nil {
+		c.responseCallback(resp)
+	}
+}
@@ -0,0 +1,139 @@
+package honeybadgerapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithResponse(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("X-Request-Id", "req-abc123")
+		w.Header().Set("X-RateLimit-Limit", "360")
+		w.Header().Set("X-RateLimit-Remaining", "10")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id": 1, "project_id": 123}`))
+	}))
+	defer server.Close()
+
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token").
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond})
+
+	var meta Response
+	if _, err := client.Faults.Get(WithResponse(context.Background(), &meta), 123, 1); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if meta.Operation != "Faults.Get" {
+		t.Errorf("expected operation Faults.Get, got %s", meta.Operation)
+	}
+	if meta.StatusCode != http.StatusOK {
+		t.Errorf("expected status code 200, got %d", meta.StatusCode)
+	}
+	if meta.RequestID != "req-abc123" {
+		t.Errorf("expected request ID req-abc123, got %s", meta.RequestID)
+	}
+	if meta.Attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d", meta.Attempts)
+	}
+	if meta.Latency <= 0 {
+		t.Errorf("expected positive latency, got %v", meta.Latency)
+	}
+	if meta.RateLimit.Remaining != 10 {
+		t.Errorf("expected rate limit remaining 10, got %d", meta.RateLimit.Remaining)
+	}
+	if meta.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("expected Content-Type header, got %s", meta.Header.Get("Content-Type"))
+	}
+}
+
+func TestWithResponse_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-missing")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token")
+
+	var meta Response
+	if _, err := client.Projects.Get(WithResponse(context.Background(), &meta), 1); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	if meta.StatusCode != http.StatusNotFound {
+		t.Errorf("expected status code 404, got %d", meta.StatusCode)
+	}
+	if meta.RequestID != "req-missing" {
+		t.Errorf("expected request ID req-missing, got %s", meta.RequestID)
+	}
+	if meta.Attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", meta.Attempts)
+	}
+}
+
+func TestWithResponseCallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	var responses []*Response
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token").
+		WithResponseCallback(func(resp *Response) {
+			responses = append(responses, resp)
+		})
+
+	if err := client.Teams.Delete(context.Background(), 1); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := client.Environments.Delete(context.Background(), 123, 2); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	if len(responses) != 2 {
+		t.Fatalf("expected 2 responses, got %d", len(responses))
+	}
+	if responses[0].Operation != "Teams.Delete" || responses[1].Operation != "Environments.Delete" {
+		t.Errorf("expected Teams.Delete and Environments.Delete, got %s and %s", responses[0].Operation, responses[1].Operation)
+	}
+	if responses[1].StatusCode != http.StatusNoContent {
+		t.Errorf("expected status code 204, got %d", responses[1].StatusCode)
+	}
+}
+
+func TestWithResponse_NetworkError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token")
+
+	var meta Response
+	if _, err := client.Projects.Get(WithResponse(context.Background(), &meta), 1); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	if meta.StatusCode != 0 {
+		t.Errorf("expected status code 0, got %d", meta.StatusCode)
+	}
+	if meta.Attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", meta.Attempts)
+	}
+}
@@ -0,0 +1,238 @@
+package honeybadgerapi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how the client retries failed requests.
+//
+// The zero value disables retries. A request is retried when the server
+// responds with 429 or a 5xx gateway/server error, or when the request fails
+// before a response is received. Only idempotent methods (GET, HEAD, OPTIONS,
+// PUT, DELETE) are retried unless RetryNonIdempotent is set.
+type RetryPolicy struct {
+	MaxAttempts        int              // Total attempts including the first; values below 2 disable retries
+	MinBackoff         time.Duration    // Delay before the first retry; doubles on each subsequent retry. Defaults to 500ms
+	MaxBackoff         time.Duration    // Upper bound for the computed backoff delay; zero means no bound
+	Jitter             float64          // Fraction (0-1) of each computed delay that is randomized
+	RetryNonIdempotent bool             // Also retry POST and PATCH requests
+	OnRetry            func(RetryEvent) // Optional hook called before sleeping for each retry
+}
+
+// RetryEvent describes a failed attempt that is about to be retried
+type RetryEvent struct {
+	Method     string        // HTTP method of the request
+	URL        string        // Full request URL
+	Attempt    int           // Number of the attempt that failed, starting at 1
+	StatusCode int           // Response status code, or 0 if no response was received
+	Err        error         // Transport error, if no response was received
+	Wait       time.Duration // Delay before the next attempt
+}
+
+// defaultMinBackoff is the first retry delay when RetryPolicy.MinBackoff is not
+// set, so that a policy without one does not retry in a tight loop
+const defaultMinBackoff = 500 * time.Millisecond
+
+// DefaultRetryPolicy returns a retry policy suitable for most callers:
+// up to 4 attempts with exponential backoff from 500ms to 30s and 20% jitter.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 4,
+		MinBackoff:  defaultMinBackoff,
+		MaxBackoff:  30 * time.Second,
+		Jitter:      0.2,
+	}
+}
+
+// WithRetryPolicy sets the retry policy used for every request made by the client
+func (c *Client) WithRetryPolicy(policy RetryPolicy) *Client {
+	c.retryPolicy = policy
+	return c
+}
+
+// send performs the HTTP request, retrying according to the client's retry policy.
+// It returns the number of attempts made alongside the response, whose body must
+// be closed by the caller.
+func (c *Client) send(ctx context.Context, req *http.Request) (*http.Response, int, error) {
+	policy := c.retryPolicy
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 {
+			rewound, err := rewindRequest(req)
+			if err != nil {
+				return nil, attempt - 1, err
+			}
+			req = rewound
+		}
+
+		if err := c.throttle(ctx); err != nil {
+			return nil, attempt - 1, err
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err == nil {
+			c.recordRateLimit(resp)
+		}
+		if err != nil && ctx.Err() != nil {
+			// Check if the error is due to context cancellation
+			return nil, attempt, ctx.Err()
+		}
+
+		if !policy.shouldRetry(req, attempt, resp, err) {
+			if err != nil {
+				return nil, attempt, fmt.Errorf("request failed: %w", err)
+			}
+			return resp, attempt, nil
+		}
+
+		wait := policy.backoff(attempt, resp)
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(wait).After(deadline) {
+			// Not enough time left for another attempt; report what we have
+			if err != nil {
+				return nil, attempt, fmt.Errorf("request failed: %w", err)
+			}
+			return resp, attempt, nil
+		}
+
+		event := RetryEvent{
+			Method:  req.Method,
+			URL:     req.URL.String(),
+			Attempt: attempt,
+			Err:     err,
+			Wait:    wait,
+		}
+		if resp != nil {
+			event.StatusCode = resp.StatusCode
+			// Drain the body so the connection can be reused
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+		if policy.OnRetry != nil {
+			policy.OnRetry(event)
+		}
+
+		if err := sleep(ctx, wait); err != nil {
+			return nil, attempt, err
+		}
+	}
+}
+
+// shouldRetry reports whether a failed attempt should be retried
+func (p RetryPolicy) shouldRetry(req *http.Request, attempt int, resp *http.Response, err error) bool {
+	if attempt >= p.MaxAttempts {
+		return false
+	}
+	if !p.RetryNonIdempotent && !isIdempotent(req.Method) {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		// The body cannot be replayed
+		return false
+	}
+	if err != nil {
+		return true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// backoff returns the delay before the attempt following the given one.
+// A Retry-After header on 429 and 503 responses takes precedence over the
+// computed exponential delay.
+func (p RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			return wait
+		}
+	}
+
+	wait := p.MinBackoff
+	if wait <= 0 {
+		wait = defaultMinBackoff
+	}
+	for i := 1; i < attempt; i++ {
+		if p.MaxBackoff > 0 && wait >= p.MaxBackoff {
+			break
+		}
+		if wait > math.MaxInt64/2 {
+			// Doubling again would overflow
+			wait = math.MaxInt64
+			break
+		}
+		wait *= 2
+	}
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+
+	if p.Jitter > 0 && wait > 0 {
+		jitter := p.Jitter
+		if jitter > 1 {
+			jitter = 1
+		}
+		wait -= time.Duration(rand.Float64() * jitter * float64(wait))
+	}
+
+	return wait
+}
+
+// parseRetryAfter parses a Retry-After header given either as delay seconds or an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := date.Sub(now)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// isIdempotent reports whether requests with the given method can be safely repeated
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// rewindRequest returns a copy of req with a fresh body, ready to be sent again
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	clone := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("failed to rewind request body: %w", err)
+		}
+		clone.Body = body
+	}
+	return clone, nil
+}
@@ -0,0 +1,305 @@
+package honeybadgerapi
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  5 * time.Millisecond,
+	}
+}
+
+func TestRetry_RecoversFromServerError(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id": 1, "name": "Retried"}`))
+	}))
+	defer server.Close()
+
+	var events []RetryEvent
+	policy := testRetryPolicy()
+	policy.OnRetry = func(e RetryEvent) { events = append(events, e) }
+
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token").
+		WithRetryPolicy(policy)
+
+	project, err := client.Projects.Get(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if project.Name != "Retried" {
+		t.Errorf("expected project name Retried, got %s", project.Name)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected 2 retry events, got %d", len(events))
+	}
+	if events[0].Attempt != 1 || events[1].Attempt != 2 {
+		t.Errorf("expected attempts 1 and 2, got %d and %d", events[0].Attempt, events[1].Attempt)
+	}
+	if events[0].StatusCode != http.StatusBadGateway {
+		t.Errorf("expected status code 502, got %d", events[0].StatusCode)
+	}
+}
+
+func TestRetry_GivesUpAfterMaxAttempts(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"errors": "Down for maintenance"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token").
+		WithRetryPolicy(testRetryPolicy())
+
+	_, err := client.Projects.Get(context.Background(), 1)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		t.Fatalf("expected APIError, got %T", err)
+	}
+	if apiErr.Message != "Down for maintenance" {
+		t.Errorf("expected error message 'Down for maintenance', got %s", apiErr.Message)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestRetry_NonIdempotentNotRetried(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token").
+		WithRetryPolicy(testRetryPolicy())
+
+	_, err := client.Comments.Create(context.Background(), 1, 2, "hello")
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 attempt for POST, got %d", calls)
+	}
+}
+
+func TestRetry_NonIdempotentOptIn(t *testing.T) {
+	var calls int32
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": 5, "body": "hello"}`))
+	}))
+	defer server.Close()
+
+	policy := testRetryPolicy()
+	policy.RetryNonIdempotent = true
+
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token").
+		WithRetryPolicy(policy)
+
+	comment, err := client.Comments.Create(context.Background(), 1, 2, "hello")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if comment.ID != 5 {
+		t.Errorf("expected comment ID 5, got %d", comment.ID)
+	}
+	if len(bodies) != 2 || bodies[0] != bodies[1] || bodies[1] == "" {
+		t.Errorf("expected identical non-empty request bodies on retry, got %q", bodies)
+	}
+}
+
+func TestRetry_HonorsRetryAfter(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	var waited time.Duration
+	policy := testRetryPolicy()
+	policy.OnRetry = func(e RetryEvent) { waited = e.Wait }
+
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token").
+		WithRetryPolicy(policy)
+
+	if err := client.Teams.Delete(context.Background(), 1); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if waited != time.Second {
+		t.Errorf("expected wait of 1s from Retry-After, got %v", waited)
+	}
+}
+
+func TestRetry_RespectsContextDeadline(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token").
+		WithRetryPolicy(testRetryPolicy())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.Projects.Get(ctx, 1)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected to give up immediately, took %v", elapsed)
+	}
+	if apiErr, ok := err.(*APIError); !ok || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected 429 APIError, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 attempt, got %d", calls)
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{
+		MinBackoff: 100 * time.Millisecond,
+		MaxBackoff: time.Second,
+	}
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{4, 800 * time.Millisecond},
+		{5, time.Second},
+		{10, time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := policy.backoff(tt.attempt, nil); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		got := policy.backoff(2, nil)
+		if got < 100*time.Millisecond || got > 200*time.Millisecond {
+			t.Fatalf("backoff with jitter out of range: %v", got)
+		}
+	}
+}
+
+func TestRetryPolicy_BackoffWithoutMax(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, MinBackoff: time.Second}
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{4, 8 * time.Second},
+		{200, time.Duration(math.MaxInt64)},
+	}
+
+	for _, tt := range tests {
+		if got := policy.backoff(tt.attempt, nil); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+
+	// A zero MinBackoff falls back to the default instead of not waiting
+	policy.MinBackoff = 0
+	if got := policy.backoff(1, nil); got != defaultMinBackoff {
+		t.Errorf("backoff(1) with zero MinBackoff = %v, want %v", got, defaultMinBackoff)
+	}
+	if got := policy.backoff(2, nil); got != 2*defaultMinBackoff {
+		t.Errorf("backoff(2) with zero MinBackoff = %v, want %v", got, 2*defaultMinBackoff)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"empty", "", 0, false},
+		{"seconds", "30", 30 * time.Second, true},
+		{"negative seconds", "-1", 0, false},
+		{"http date", "Mon, 01 Jan 2024 00:00:10 GMT", 10 * time.Second, true},
+		{"past http date", "Sun, 31 Dec 2023 23:59:00 GMT", 0, true},
+		{"garbage", "soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.value, now)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("parseRetryAfter(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
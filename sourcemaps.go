@@ -0,0 +1,260 @@
+package honeybadgerapi
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime/multipart"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// SourceMapsService uploads JavaScript source maps. Uploads go to the
+// Reporting API and authenticate with the project API key set by WithAPIKey.
+type SourceMapsService struct {
+	client *Client
+}
+
+// DefaultUploadConcurrency is the number of uploads UploadDir keeps in flight when none is set
+const DefaultUploadConcurrency = 4
+
+// SourceMapUpload describes a minified file and its source map. The files
+// are streamed from disk rather than read into memory.
+type SourceMapUpload struct {
+	MinifiedURL  string // URL the minified file is served from; may contain wildcards, e.g. https://*.example.com/app.js
+	Revision     string // Revision of the deployed code, e.g. a git SHA; optional
+	MinifiedFile string // Path of the minified JavaScript file
+	SourceMap    string // Path of the source map
+}
+
+// validate checks that the required fields are set and the files exist
+func (u SourceMapUpload) validate() error {
+	if u.MinifiedURL == "" {
+		return errors.New("source map upload requires a minified URL")
+	}
+	for _, file := range []string{u.MinifiedFile, u.SourceMap} {
+		if file == "" {
+			return errors.New("source map upload requires a minified file and a source map")
+		}
+		if _, err := os.Stat(file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Upload uploads a source map and its minified file as a streamed multipart request.
+//
+// Honeybadger API docs: https://docs.honeybadger.io/api/reporting-source-maps/
+//
+// POST /v1/source_maps
+func (s *SourceMapsService) Upload(ctx context.Context, upload SourceMapUpload) error {
+	ctx = withOperation(ctx, "SourceMaps.Upload", 0, upload.MinifiedURL)
+
+	if err := upload.validate(); err != nil {
+		return err
+	}
+
+	// The transport closes the bodies it sends, but a body that never reaches
+	// it, e.g. when the rate limiter gives up or a middleware returns early,
+	// would leave its writer blocked with the files open. Close them all.
+	var mu sync.Mutex
+	var bodies []io.ReadCloser
+	defer func() {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, body := range bodies {
+			_ = body.Close()
+		}
+	}()
+
+	boundary := upload.boundary()
+	open := func() (io.ReadCloser, error) {
+		body := streamSourceMapForm(upload, s.client.apiKey, boundary)
+		mu.Lock()
+		bodies = append(bodies, body)
+		mu.Unlock()
+		return body, nil
+	}
+
+	body, _ := open()
+	req, err := s.client.newReportingRawRequest(ctx, "POST", "/source_maps", "multipart/form-data; boundary="+boundary, body, upload)
+	if err != nil {
+		return err
+	}
+	req.GetBody = open
+
+	// Upload returns 201 Created.
+	return s.client.do(ctx, req, nil)
+}
+
+// boundary returns a multipart boundary derived from the minified URL,
+// revision and file names, so the body is the same for retries and for
+// uploads of the same files from another directory
+func (u SourceMapUpload) boundary() string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{u.MinifiedURL, u.Revision, filepath.Base(u.MinifiedFile), filepath.Base(u.SourceMap)}, "\x00")))
+	return "honeybadger-" + hex.EncodeToString(sum[:16])
+}
+
+// streamSourceMapForm returns a reader that produces the multipart form for an upload as it is read
+func streamSourceMapForm(upload SourceMapUpload, apiKey, boundary string) io.ReadCloser {
+	pr, pw := io.Pipe()
+	go func() {
+		mw := multipart.NewWriter(pw)
+		err := mw.SetBoundary(boundary)
+		if err == nil {
+			err = writeSourceMapForm(mw, upload, apiKey)
+		}
+		if err == nil {
+			err = mw.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+	return pr
+}
+
+// writeSourceMapForm writes the form fields and files
+func writeSourceMapForm(mw *multipart.Writer, upload SourceMapUpload, apiKey string) error {
+	fields := [][2]string{
+		{"api_key", apiKey},
+		{"minified_url", upload.MinifiedURL},
+	}
+	if upload.Revision != "" {
+		fields = append(fields, [2]string{"revision", upload.Revision})
+	}
+	for _, field := range fields {
+		if err := mw.WriteField(field[0], field[1]); err != nil {
+			return err
+		}
+	}
+
+	files := [][2]string{
+		{"minified_file", upload.MinifiedFile},
+		{"source_map", upload.SourceMap},
+	}
+	for _, file := range files {
+		if err := copyFormFile(mw, file[0], file[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyFormFile(mw *multipart.Writer, field, name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	part, err := mw.CreateFormFile(field, filepath.Base(name))
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(part, f)
+	return err
+}
+
+// SourceMapDirOptions controls how UploadDir uploads a directory of source maps
+type SourceMapDirOptions struct {
+	BaseURL     string // URL the directory is served from; each file's path is appended to it
+	Revision    string // Revision sent with every upload
+	Concurrency int    // Uploads in flight at once; defaults to DefaultUploadConcurrency
+}
+
+// SourceMapUploadResult is the outcome of uploading a single source map
+type SourceMapUploadResult struct {
+	Upload SourceMapUpload
+	Err    error // Nil if the upload succeeded
+}
+
+// SourceMapUploadReport summarizes a directory upload
+type SourceMapUploadReport struct {
+	Results  []SourceMapUploadResult // One per *.js file with a matching *.js.map, in walk order
+	Unpaired []string                // *.js files without a source map, which were not uploaded
+}
+
+// Failed returns the results of uploads that failed
+func (r *SourceMapUploadReport) Failed() []SourceMapUploadResult {
+	var failed []SourceMapUploadResult
+	for _, result := range r.Results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Err returns the failed uploads joined into one error, or nil if all succeeded
+func (r *SourceMapUploadReport) Err() error {
+	var errs []error
+	for _, result := range r.Failed() {
+		errs = append(errs, fmt.Errorf("%s: %w", result.Upload.MinifiedFile, result.Err))
+	}
+	return errors.Join(errs...)
+}
+
+// UploadDir walks dir, pairs each *.js file with the *.js.map file next to it
+// and uploads the pairs concurrently. The minified URL of each file is
+// BaseURL joined with its path relative to dir.
+//
+// The returned error reports a failure to walk the directory; per-file
+// failures are recorded in the report.
+func (s *SourceMapsService) UploadDir(ctx context.Context, dir string, options SourceMapDirOptions) (*SourceMapUploadReport, error) {
+	if options.BaseURL == "" {
+		return nil, errors.New("source map directory upload requires a base URL")
+	}
+
+	report := &SourceMapUploadReport{}
+	err := filepath.WalkDir(dir, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(name, ".js") {
+			return nil
+		}
+
+		if _, err := os.Stat(name + ".map"); errors.Is(err, fs.ErrNotExist) {
+			report.Unpaired = append(report.Unpaired, name)
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, name)
+		if err != nil {
+			return err
+		}
+		report.Results = append(report.Results, SourceMapUploadResult{Upload: SourceMapUpload{
+			MinifiedURL:  strings.TrimSuffix(options.BaseURL, "/") + "/" + path.Clean(filepath.ToSlash(rel)),
+			Revision:     options.Revision,
+			MinifiedFile: name,
+			SourceMap:    name + ".map",
+		}})
+		return nil
+	})
+	if err != nil {
+		return report, err
+	}
+
+	concurrency := options.Concurrency
+	if concurrency < 1 {
+		concurrency = DefaultUploadConcurrency
+	}
+
+	errs := forEachConcurrently(ctx, len(report.Results), concurrency, func(i int) error {
+		return s.Upload(ctx, report.Results[i].Upload)
+	})
+	for i, err := range errs {
+		report.Results[i].Err = err
+	}
+
+	return report, nil
+}
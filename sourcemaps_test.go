@@ -0,0 +1,225 @@
+package honeybadgerapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// sourceMapsServer records uploads keyed by minified URL. Uploads whose
+// minified URL is in fail are rejected with a 422.
+type sourceMapsServer struct {
+	*httptest.Server
+	mu      sync.Mutex
+	uploads map[string]map[string]string // Form values and file contents by field name
+	fail    map[string]bool
+}
+
+func newSourceMapsServer(t *testing.T) *sourceMapsServer {
+	t.Helper()
+	s := &sourceMapsServer{uploads: map[string]map[string]string{}, fail: map[string]bool{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/v1/source_maps" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("X-API-Key") != "project-key" {
+			t.Errorf("expected X-API-Key project-key, got %s", r.Header.Get("X-API-Key"))
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Errorf("failed to parse multipart form: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		upload := map[string]string{}
+		for field, values := range r.MultipartForm.Value {
+			upload[field] = values[0]
+		}
+		for field, files := range r.MultipartForm.File {
+			f, _ := files[0].Open()
+			data, _ := io.ReadAll(f)
+			f.Close()
+			upload[field] = string(data)
+			upload[field+"_name"] = files[0].Filename
+		}
+
+		s.mu.Lock()
+		s.uploads[upload["minified_url"]] = upload
+		fail := s.fail[upload["minified_url"]]
+		s.mu.Unlock()
+
+		if fail {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"errors": "Invalid source map"}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestSourceMapsUpload(t *testing.T) {
+	server := newSourceMapsServer(t)
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		"app.min.js":     "console.log(1)",
+		"app.min.js.map": `{"version":3}`,
+	})
+
+	client := NewClient().
+		WithReportingURL(server.URL).
+		WithAPIKey("project-key")
+
+	err := client.SourceMaps.Upload(context.Background(), SourceMapUpload{
+		MinifiedURL:  "https://example.com/assets/app.min.js",
+		Revision:     "abc123",
+		MinifiedFile: filepath.Join(dir, "app.min.js"),
+		SourceMap:    filepath.Join(dir, "app.min.js.map"),
+	})
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+
+	upload := server.uploads["https://example.com/assets/app.min.js"]
+	expected := map[string]string{
+		"api_key":            "project-key",
+		"revision":           "abc123",
+		"minified_file":      "console.log(1)",
+		"minified_file_name": "app.min.js",
+		"source_map":         `{"version":3}`,
+		"source_map_name":    "app.min.js.map",
+	}
+	for field, value := range expected {
+		if upload[field] != value {
+			t.Errorf("expected %s %q, got %q", field, value, upload[field])
+		}
+	}
+}
+
+func TestSourceMapsUpload_ClosesUnsentBodies(t *testing.T) {
+	server := newSourceMapsServer(t)
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		"app.min.js":     "console.log(1)",
+		"app.min.js.map": `{"version":3}`,
+	})
+	upload := SourceMapUpload{
+		MinifiedURL:  "https://example.com/assets/app.min.js",
+		MinifiedFile: filepath.Join(dir, "app.min.js"),
+		SourceMap:    filepath.Join(dir, "app.min.js.map"),
+	}
+
+	// Use up the only token so later uploads wait on the rate limiter
+	client := NewClient().
+		WithReportingURL(server.URL).
+		WithAPIKey("project-key").
+		WithRateLimit(0.001, 1)
+	if err := client.SourceMaps.Upload(context.Background(), upload); err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+	server.CloseClientConnections()
+	before := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for i := 0; i < 5; i++ {
+		if err := client.SourceMaps.Upload(ctx, upload); !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("expected at most %d goroutines, got %d", before, after)
+	}
+}
+
+func TestSourceMapsUpload_Invalid(t *testing.T) {
+	client := NewClient().WithAPIKey("project-key")
+	ctx := context.Background()
+
+	if err := client.SourceMaps.Upload(ctx, SourceMapUpload{MinifiedFile: "app.js", SourceMap: "app.js.map"}); err == nil {
+		t.Error("expected error without a minified URL, got nil")
+	}
+
+	err := client.SourceMaps.Upload(ctx, SourceMapUpload{
+		MinifiedURL:  "https://example.com/app.js",
+		MinifiedFile: filepath.Join(t.TempDir(), "missing.js"),
+		SourceMap:    filepath.Join(t.TempDir(), "missing.js.map"),
+	})
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected a missing file error, got %v", err)
+	}
+}
+
+func TestSourceMapsUploadDir(t *testing.T) {
+	server := newSourceMapsServer(t)
+	server.fail["https://cdn.example.com/assets/vendor/lib.js"] = true
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		"app.js":            "app",
+		"app.js.map":        "app map",
+		"vendor/lib.js":     "lib",
+		"vendor/lib.js.map": "lib map",
+		"no-map.js":         "no map",
+		"styles.css":        "body {}",
+	})
+
+	client := NewClient().
+		WithReportingURL(server.URL).
+		WithAPIKey("project-key")
+
+	report, err := client.SourceMaps.UploadDir(context.Background(), dir, SourceMapDirOptions{
+		BaseURL:     "https://cdn.example.com/assets/",
+		Revision:    "abc123",
+		Concurrency: 2,
+	})
+	if err != nil {
+		t.Fatalf("UploadDir() error = %v", err)
+	}
+
+	var urls []string
+	for url := range server.uploads {
+		urls = append(urls, url)
+	}
+	sort.Strings(urls)
+	if len(urls) != 2 || urls[0] != "https://cdn.example.com/assets/app.js" || urls[1] != "https://cdn.example.com/assets/vendor/lib.js" {
+		t.Errorf("unexpected uploads %v", urls)
+	}
+	if server.uploads["https://cdn.example.com/assets/vendor/lib.js"]["source_map"] != "lib map" {
+		t.Error("expected lib.js to be paired with lib.js.map")
+	}
+
+	if len(report.Unpaired) != 1 || filepath.Base(report.Unpaired[0]) != "no-map.js" {
+		t.Errorf("expected no-map.js to be unpaired, got %v", report.Unpaired)
+	}
+	failed := report.Failed()
+	if len(failed) != 1 || filepath.Base(failed[0].Upload.MinifiedFile) != "lib.js" {
+		t.Fatalf("expected lib.js to fail, got %+v", failed)
+	}
+	if !errors.Is(report.Err(), ErrValidation) {
+		t.Errorf("expected report error to wrap ErrValidation, got %v", report.Err())
+	}
+}
+
+func TestSourceMapsUploadDir_WalkError(t *testing.T) {
+	client := NewClient().WithAPIKey("project-key")
+
+	_, err := client.SourceMaps.UploadDir(context.Background(), filepath.Join(t.TempDir(), "missing"), SourceMapDirOptions{BaseURL: "https://example.com"})
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected a missing directory error, got %v", err)
+	}
+}
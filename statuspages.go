@@ -12,6 +12,8 @@ type StatusPagesService struct {
 
 // List retrieves all status pages for an account
 func (s *StatusPagesService) List(ctx context.Context, accountID string) ([]StatusPage, error) {
+	ctx = withOperation(ctx, "StatusPages.List", 0, accountID)
+
 	path := fmt.Sprintf("/accounts/%s/status_pages", accountID)
 
 	req, err := s.client.newRequest(ctx, "GET", path, nil)
@@ -29,6 +31,8 @@ func (s *StatusPagesService) List(ctx context.Context, accountID string) ([]Stat
 
 // Get retrieves a single status page by ID
 func (s *StatusPagesService) Get(ctx context.Context, accountID string, statusPageID string) (*StatusPage, error) {
+	ctx = withOperation(ctx, "StatusPages.Get", 0, statusPageID)
+
 	path := fmt.Sprintf("/accounts/%s/status_pages/%s", accountID, statusPageID)
 
 	req, err := s.client.newRequest(ctx, "GET", path, nil)
@@ -46,6 +50,8 @@ func (s *StatusPagesService) Get(ctx context.Context, accountID string, statusPa
 
 // Create creates a new status page
 func (s *StatusPagesService) Create(ctx context.Context, accountID string, params StatusPageParams) (*StatusPage, error) {
+	ctx = withOperation(ctx, "StatusPages.Create", 0, accountID)
+
 	path := fmt.Sprintf("/accounts/%s/status_pages", accountID)
 
 	reqBody := StatusPageRequest{StatusPage: params}
@@ -65,6 +71,8 @@ func (s *StatusPagesService) Create(ctx context.Context, accountID string, param
 
 // Update updates an existing status page
 func (s *StatusPagesService) Update(ctx context.Context, accountID string, statusPageID string, params StatusPageParams) error {
+	ctx = withOperation(ctx, "StatusPages.Update", 0, statusPageID)
+
 	path := fmt.Sprintf("/accounts/%s/status_pages/%s", accountID, statusPageID)
 
 	reqBody := StatusPageRequest{StatusPage: params}
@@ -80,6 +88,8 @@ func (s *StatusPagesService) Update(ctx context.Context, accountID string, statu
 
 // Delete deletes a status page
 func (s *StatusPagesService) Delete(ctx context.Context, accountID string, statusPageID string) error {
+	ctx = withOperation(ctx, "StatusPages.Delete", 0, statusPageID)
+
 	path := fmt.Sprintf("/accounts/%s/status_pages/%s", accountID, statusPageID)
 
 	req, err := s.client.newRequest(ctx, "DELETE", path, nil)
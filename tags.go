@@ -0,0 +1,110 @@
+package honeybadgerapi
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// NormalizeTags returns tags in the form Honeybadger stores them. It follows
+// the server's rules:
+//
+//   - values are split on commas and whitespace, and empty tags are dropped
+//   - tags are case-insensitive and stored in lower case, so "Billing" and
+//     "billing" are the same tag
+//   - punctuation such as ":", "-" or "." is kept as part of the tag
+//
+// The result is de-duplicated and sorted so that tag lists compare cleanly.
+// It is never nil, so it can be used to clear a fault's tags.
+func NormalizeTags(tags ...string) []string {
+	normalized := []string{}
+	for _, tag := range tags {
+		for _, field := range strings.FieldsFunc(tag, isTagSeparator) {
+			normalized = append(normalized, strings.ToLower(field))
+		}
+	}
+	slices.Sort(normalized)
+	return slices.Compact(normalized)
+}
+
+func isTagSeparator(r rune) bool {
+	return r == ',' || unicode.IsSpace(r)
+}
+
+// addTags returns the normalized union of existing and added tags, and
+// whether it differs from the existing tags
+func addTags(existing []string, tags []string) ([]string, bool) {
+	merged := NormalizeTags(append(slices.Clone(existing), tags...)...)
+	return merged, !slices.Equal(merged, NormalizeTags(existing...))
+}
+
+// removeTags returns the normalized existing tags without the removed ones,
+// and whether it differs from the existing tags
+func removeTags(existing []string, tags []string) ([]string, bool) {
+	before := NormalizeTags(existing...)
+	removed := NormalizeTags(tags...)
+	remaining := slices.DeleteFunc(slices.Clone(before), func(tag string) bool {
+		_, found := slices.BinarySearch(removed, tag)
+		return found
+	})
+	return remaining, len(remaining) != len(before)
+}
+
+// AddTags adds tags to a fault, keeping its existing tags, and returns the
+// fault's normalized tags. No update is sent if the fault already has them all.
+func (f *FaultsService) AddTags(ctx context.Context, projectID, faultID int, tags ...string) ([]string, error) {
+	fault, err := f.Get(ctx, projectID, faultID)
+	if err != nil {
+		return nil, err
+	}
+
+	merged, changed := addTags(fault.Tags, tags)
+	if !changed {
+		return merged, nil
+	}
+	if err := f.Update(ctx, projectID, faultID, FaultUpdateParams{Tags: merged}); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+// RemoveTags removes tags from a fault and returns the fault's remaining
+// normalized tags. No update is sent if the fault has none of them.
+func (f *FaultsService) RemoveTags(ctx context.Context, projectID, faultID int, tags ...string) ([]string, error) {
+	fault, err := f.Get(ctx, projectID, faultID)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining, changed := removeTags(fault.Tags, tags)
+	if !changed {
+		return remaining, nil
+	}
+	if err := f.Update(ctx, projectID, faultID, FaultUpdateParams{Tags: remaining}); err != nil {
+		return nil, err
+	}
+	return remaining, nil
+}
+
+// ReplaceTags sets a fault's tags, removing any others, and returns the
+// normalized tags that were sent. Calling it with no tags clears them.
+func (f *FaultsService) ReplaceTags(ctx context.Context, projectID, faultID int, tags ...string) ([]string, error) {
+	normalized := NormalizeTags(tags...)
+	if err := f.Update(ctx, projectID, faultID, FaultUpdateParams{Tags: normalized}); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// BulkTag adds tags to every fault matching a query. It is shorthand for
+// BulkUpdate with TagAction.
+func (f *FaultsService) BulkTag(ctx context.Context, projectID int, query *FaultQueryBuilder, tags []string, bulkOptions BulkUpdateOptions) (*BulkUpdateReport, error) {
+	return f.BulkUpdate(ctx, projectID, FaultListOptions{Query: query}, TagAction(tags...), bulkOptions)
+}
+
+// BulkUntag removes tags from every fault matching a query. It is shorthand
+// for BulkUpdate with UntagAction.
+func (f *FaultsService) BulkUntag(ctx context.Context, projectID int, query *FaultQueryBuilder, tags []string, bulkOptions BulkUpdateOptions) (*BulkUpdateReport, error) {
+	return f.BulkUpdate(ctx, projectID, FaultListOptions{Query: query}, UntagAction(tags...), bulkOptions)
+}
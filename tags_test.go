@@ -0,0 +1,147 @@
+package honeybadgerapi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tests := []struct {
+		input    []string
+		expected string
+	}{
+		{[]string{"billing", "api"}, "[api billing]"},
+		{[]string{"a,b", " c  d ", "a"}, "[a b c d]"},
+		{[]string{"", " , "}, "[]"},
+		{[]string{"Billing, billing", "BILLING"}, "[billing]"},
+		{[]string{"routing:SRE", "p-1,v1.2;x", "team_a."}, "[p-1 routing:sre team_a. v1.2;x]"},
+		{nil, "[]"},
+	}
+	for _, tt := range tests {
+		got := NormalizeTags(tt.input...)
+		if got == nil {
+			t.Errorf("NormalizeTags(%q) returned nil", tt.input)
+		}
+		if fmt.Sprint(got) != tt.expected {
+			t.Errorf("NormalizeTags(%q): expected %s, got %v", tt.input, tt.expected, got)
+		}
+	}
+}
+
+// newTagsServer serves fault 456 with the given tags and records update bodies
+func newTagsServer(t *testing.T, tags string, updates *[]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/projects/123/faults/456" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		switch r.Method {
+		case "GET":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"id": 456, "tags": %s}`, tags)
+		case "PUT":
+			body, _ := io.ReadAll(r.Body)
+			*updates = append(*updates, string(body))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestAddTags(t *testing.T) {
+	var updates []string
+	server := newTagsServer(t, `["db", "slow"]`, &updates)
+
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token")
+
+	tags, err := client.Faults.AddTags(context.Background(), 123, 456, "billing, slow")
+	if err != nil {
+		t.Fatalf("AddTags() error = %v", err)
+	}
+	if fmt.Sprint(tags) != "[billing db slow]" {
+		t.Errorf("expected tags [billing db slow], got %v", tags)
+	}
+	if len(updates) != 1 || updates[0] != `{"fault":{"tags":["billing","db","slow"]}}` {
+		t.Errorf("unexpected updates %v", updates)
+	}
+
+	// Adding tags the fault already has sends no update
+	if _, err := client.Faults.AddTags(context.Background(), 123, 456, "db"); err != nil {
+		t.Fatalf("AddTags() error = %v", err)
+	}
+	if len(updates) != 1 {
+		t.Errorf("expected no further updates, got %v", updates)
+	}
+}
+
+func TestRemoveTags(t *testing.T) {
+	var updates []string
+	server := newTagsServer(t, `["db", "slow"]`, &updates)
+
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token")
+
+	tags, err := client.Faults.RemoveTags(context.Background(), 123, 456, "slow", "missing")
+	if err != nil {
+		t.Fatalf("RemoveTags() error = %v", err)
+	}
+	if fmt.Sprint(tags) != "[db]" {
+		t.Errorf("expected tags [db], got %v", tags)
+	}
+	if len(updates) != 1 || updates[0] != `{"fault":{"tags":["db"]}}` {
+		t.Errorf("unexpected updates %v", updates)
+	}
+
+	if _, err := client.Faults.RemoveTags(context.Background(), 123, 456, "missing"); err != nil {
+		t.Fatalf("RemoveTags() error = %v", err)
+	}
+	if len(updates) != 1 {
+		t.Errorf("expected no further updates, got %v", updates)
+	}
+}
+
+func TestReplaceTags(t *testing.T) {
+	var updates []string
+	server := newTagsServer(t, `["db"]`, &updates)
+
+	client := NewClient().
+		WithBaseURL(server.URL).
+		WithAuthToken("test-token")
+
+	if _, err := client.Faults.ReplaceTags(context.Background(), 123, 456, "b", "a"); err != nil {
+		t.Fatalf("ReplaceTags() error = %v", err)
+	}
+	if _, err := client.Faults.ReplaceTags(context.Background(), 123, 456); err != nil {
+		t.Fatalf("ReplaceTags() error = %v", err)
+	}
+
+	expected := []string{`{"fault":{"tags":["a","b"]}}`, `{"fault":{"tags":[]}}`}
+	if fmt.Sprint(updates) != fmt.Sprint(expected) {
+		t.Errorf("expected updates %v, got %v", expected, updates)
+	}
+}
+
+func TestUntagAction(t *testing.T) {
+	fault := Fault{Tags: []string{"b", "a"}}
+
+	params, changed := UntagAction("a").params(fault)
+	if !changed || fmt.Sprint(params.Tags) != "[b]" {
+		t.Errorf("expected tags [b], got %v (changed %v)", params.Tags, changed)
+	}
+	if _, changed := UntagAction("c").params(fault); changed {
+		t.Error("expected untag to skip a fault without the tag")
+	}
+	if fault.Tags[0] != "b" {
+		t.Errorf("expected the listed fault's tags to be left alone, got %v", fault.Tags)
+	}
+}
@@ -13,6 +13,8 @@ type TeamsService struct {
 
 // List retrieves all teams for an account
 func (s *TeamsService) List(ctx context.Context, accountID string) ([]Team, error) {
+	ctx = withOperation(ctx, "Teams.List", 0, accountID)
+
 	path := "/teams"
 
 	params := url.Values{}
@@ -34,6 +36,8 @@ func (s *TeamsService) List(ctx context.Context, accountID string) ([]Team, erro
 
 // Get retrieves a single team by ID
 func (s *TeamsService) Get(ctx context.Context, teamID int) (*Team, error) {
+	ctx = withOperation(ctx, "Teams.Get", 0, teamID)
+
 	path := fmt.Sprintf("/teams/%d", teamID)
 
 	req, err := s.client.newRequest(ctx, "GET", path, nil)
@@ -51,6 +55,8 @@ func (s *TeamsService) Get(ctx context.Context, teamID int) (*Team, error) {
 
 // Create creates a new team
 func (s *TeamsService) Create(ctx context.Context, accountID string, name string) (*Team, error) {
+	ctx = withOperation(ctx, "Teams.Create", 0, accountID)
+
 	path := "/teams"
 
 	params := url.Values{}
@@ -75,6 +81,8 @@ func (s *TeamsService) Create(ctx context.Context, accountID string, name string
 
 // Update updates an existing team
 func (s *TeamsService) Update(ctx context.Context, teamID int, name string) error {
+	ctx = withOperation(ctx, "Teams.Update", 0, teamID)
+
 	path := fmt.Sprintf("/teams/%d", teamID)
 
 	reqBody := TeamRequest{}
@@ -91,6 +99,8 @@ func (s *TeamsService) Update(ctx context.Context, teamID int, name string) erro
 
 // Delete deletes a team
 func (s *TeamsService) Delete(ctx context.Context, teamID int) error {
+	ctx = withOperation(ctx, "Teams.Delete", 0, teamID)
+
 	path := fmt.Sprintf("/teams/%d", teamID)
 
 	req, err := s.client.newRequest(ctx, "DELETE", path, nil)
@@ -103,6 +113,8 @@ func (s *TeamsService) Delete(ctx context.Context, teamID int) error {
 
 // ListMembers retrieves all members of a team
 func (s *TeamsService) ListMembers(ctx context.Context, teamID int) ([]TeamMember, error) {
+	ctx = withOperation(ctx, "Teams.ListMembers", 0, teamID)
+
 	path := fmt.Sprintf("/teams/%d/team_members", teamID)
 
 	req, err := s.client.newRequest(ctx, "GET", path, nil)
@@ -120,6 +132,8 @@ func (s *TeamsService) ListMembers(ctx context.Context, teamID int) ([]TeamMembe
 
 // UpdateMember updates a team member's permissions
 func (s *TeamsService) UpdateMember(ctx context.Context, teamID, memberID int, admin bool) error {
+	ctx = withOperation(ctx, "Teams.UpdateMember", 0, memberID)
+
 	path := fmt.Sprintf("/teams/%d/team_members/%d", teamID, memberID)
 
 	reqBody := TeamMemberUpdateRequest{}
@@ -136,6 +150,8 @@ func (s *TeamsService) UpdateMember(ctx context.Context, teamID, memberID int, a
 
 // RemoveMember removes a member from a team
 func (s *TeamsService) RemoveMember(ctx context.Context, teamID, memberID int) error {
+	ctx = withOperation(ctx, "Teams.RemoveMember", 0, memberID)
+
 	path := fmt.Sprintf("/teams/%d/team_members/%d", teamID, memberID)
 
 	req, err := s.client.newRequest(ctx, "DELETE", path, nil)
@@ -148,6 +164,8 @@ func (s *TeamsService) RemoveMember(ctx context.Context, teamID, memberID int) e
 
 // ListInvitations retrieves all invitations for a team
 func (s *TeamsService) ListInvitations(ctx context.Context, teamID int) ([]TeamInvitation, error) {
+	ctx = withOperation(ctx, "Teams.ListInvitations", 0, teamID)
+
 	path := fmt.Sprintf("/teams/%d/team_invitations", teamID)
 
 	req, err := s.client.newRequest(ctx, "GET", path, nil)
@@ -165,6 +183,8 @@ func (s *TeamsService) ListInvitations(ctx context.Context, teamID int) ([]TeamI
 
 // GetInvitation retrieves a single invitation by ID
 func (s *TeamsService) GetInvitation(ctx context.Context, teamID, invitationID int) (*TeamInvitation, error) {
+	ctx = withOperation(ctx, "Teams.GetInvitation", 0, invitationID)
+
 	path := fmt.Sprintf("/teams/%d/team_invitations/%d", teamID, invitationID)
 
 	req, err := s.client.newRequest(ctx, "GET", path, nil)
@@ -182,6 +202,8 @@ func (s *TeamsService) GetInvitation(ctx context.Context, teamID, invitationID i
 
 // CreateInvitation creates a new team invitation
 func (s *TeamsService) CreateInvitation(ctx context.Context, teamID int, params TeamInvitationParams) (*TeamInvitation, error) {
+	ctx = withOperation(ctx, "Teams.CreateInvitation", 0, teamID)
+
 	path := fmt.Sprintf("/teams/%d/team_invitations", teamID)
 
 	reqBody := TeamInvitationRequest{TeamInvitation: params}
@@ -201,6 +223,8 @@ func (s *TeamsService) CreateInvitation(ctx context.Context, teamID int, params
 
 // UpdateInvitation updates an existing team invitation
 func (s *TeamsService) UpdateInvitation(ctx context.Context, teamID, invitationID int, params TeamInvitationParams) error {
+	ctx = withOperation(ctx, "Teams.UpdateInvitation", 0, invitationID)
+
 	path := fmt.Sprintf("/teams/%d/team_invitations/%d", teamID, invitationID)
 
 	reqBody := TeamInvitationRequest{TeamInvitation: params}
@@ -216,6 +240,8 @@ func (s *TeamsService) UpdateInvitation(ctx context.Context, teamID, invitationI
 
 // DeleteInvitation deletes a team invitation
 func (s *TeamsService) DeleteInvitation(ctx context.Context, teamID, invitationID int) error {
+	ctx = withOperation(ctx, "Teams.DeleteInvitation", 0, invitationID)
+
 	path := fmt.Sprintf("/teams/%d/team_invitations/%d", teamID, invitationID)
 
 	req, err := s.client.newRequest(ctx, "DELETE", path, nil)
@@ -488,6 +488,14 @@ type Deployment struct {
 	Revision      string    `json:"revision"`
 }
 
+// DeploymentParams represents the parameters for recording a deployment
+type DeploymentParams struct {
+	Environment   string `json:"environment,omitempty"`
+	Revision      string `json:"revision,omitempty"`
+	Repository    string `json:"repository,omitempty"`
+	LocalUsername string `json:"local_username,omitempty"`
+}
+
 // DeploymentListResponse represents the response from listing deployments
 type DeploymentListResponse struct {
 	Results []Deployment `json:"results"`
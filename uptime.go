@@ -14,6 +14,8 @@ type UptimeService struct {
 
 // List retrieves all uptime sites for a project
 func (s *UptimeService) List(ctx context.Context, projectID int) ([]Site, error) {
+	ctx = withOperation(ctx, "Uptime.List", projectID, nil)
+
 	path := fmt.Sprintf("/projects/%d/sites", projectID)
 
 	req, err := s.client.newRequest(ctx, "GET", path, nil)
@@ -31,6 +33,8 @@ func (s *UptimeService) List(ctx context.Context, projectID int) ([]Site, error)
 
 // Get retrieves a single uptime site by ID
 func (s *UptimeService) Get(ctx context.Context, projectID int, siteID string) (*Site, error) {
+	ctx = withOperation(ctx, "Uptime.Get", projectID, siteID)
+
 	path := fmt.Sprintf("/projects/%d/sites/%s", projectID, siteID)
 
 	req, err := s.client.newRequest(ctx, "GET", path, nil)
@@ -48,6 +52,8 @@ func (s *UptimeService) Get(ctx context.Context, projectID int, siteID string) (
 
 // Create creates a new uptime site
 func (s *UptimeService) Create(ctx context.Context, projectID int, params SiteParams) (*Site, error) {
+	ctx = withOperation(ctx, "Uptime.Create", projectID, nil)
+
 	path := fmt.Sprintf("/projects/%d/sites", projectID)
 
 	reqBody := SiteCreateRequest{Site: params}
@@ -67,6 +73,8 @@ func (s *UptimeService) Create(ctx context.Context, projectID int, params SitePa
 
 // Update updates an existing uptime site
 func (s *UptimeService) Update(ctx context.Context, projectID int, siteID string, params SiteParams) (*Site, error) {
+	ctx = withOperation(ctx, "Uptime.Update", projectID, siteID)
+
 	path := fmt.Sprintf("/projects/%d/sites/%s", projectID, siteID)
 
 	reqBody := SiteCreateRequest{Site: params}
@@ -86,6 +94,8 @@ func (s *UptimeService) Update(ctx context.Context, projectID int, siteID string
 
 // Delete deletes an uptime site
 func (s *UptimeService) Delete(ctx context.Context, projectID int, siteID string) error {
+	ctx = withOperation(ctx, "Uptime.Delete", projectID, siteID)
+
 	path := fmt.Sprintf("/projects/%d/sites/%s", projectID, siteID)
 
 	req, err := s.client.newRequest(ctx, "DELETE", path, nil)
@@ -98,6 +108,8 @@ func (s *UptimeService) Delete(ctx context.Context, projectID int, siteID string
 
 // ListOutages retrieves outages for a specific site
 func (s *UptimeService) ListOutages(ctx context.Context, projectID int, siteID string, options OutageListOptions) ([]Outage, error) {
+	ctx = withOperation(ctx, "Uptime.ListOutages", projectID, siteID)
+
 	path := fmt.Sprintf("/projects/%d/sites/%s/outages", projectID, siteID)
 
 	// Build query parameters
@@ -131,6 +143,8 @@ func (s *UptimeService) ListOutages(ctx context.Context, projectID int, siteID s
 
 // ListUptimeChecks retrieves uptime checks for a specific site
 func (s *UptimeService) ListUptimeChecks(ctx context.Context, projectID int, siteID string, options UptimeCheckListOptions) ([]UptimeCheck, error) {
+	ctx = withOperation(ctx, "Uptime.ListUptimeChecks", projectID, siteID)
+
 	path := fmt.Sprintf("/projects/%d/sites/%s/uptime_checks", projectID, siteID)
 
 	// Build query parameters